@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ravsii/textra"
+)
+
+func runCheck(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	allowPath := fs.String("allow", "", "a file with allowed changes")
+	breaking := fs.Bool("breaking", false, "fail only on breaking changes")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 2 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	snap, err := readSnapshot(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	infos, err := textra.ExtractDirInfo(fs.Arg(1))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	allow := allowlist{}
+	if *allowPath != "" {
+		if allow, err = readAllowlist(*allowPath); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+	}
+
+	unexpected := 0
+
+	for _, line := range compare(snap, textra.NewSnapshotInfo(infos)) {
+		if allow.allows(line) || (*breaking && !line.breaking()) {
+			continue
+		}
+
+		fmt.Fprintln(stdout, line)
+		unexpected++
+	}
+
+	if unexpected > 0 {
+		fmt.Fprintf(stderr, "textra: %d unexpected change(s)\n", unexpected)
+		return 1
+	}
+
+	return 0
+}
+
+// structChange is a change of a single struct. Change is nil if the whole
+// struct was added or removed.
+type structChange struct {
+	Struct  string
+	Removed bool
+	Change  *textra.Change
+}
+
+func (c structChange) breaking() bool {
+	if c.Change == nil {
+		return c.Removed
+	}
+
+	return len(c.Change.Breaking) > 0
+}
+
+func (c structChange) String() string {
+	switch {
+	case c.Change != nil:
+		return c.Struct + "." + c.Change.String()
+	case c.Removed:
+		return c.Struct + ": struct removed [breaking]"
+	default:
+		return c.Struct + ": struct added"
+	}
+}
+
+// compare returns changes between structs of two snapshots, sorted by struct
+// names. Struct metadata is compared as a field named "_".
+func compare(fromSnap, toSnap textra.Snapshot) []structChange {
+	from, to := fromSnap.Structs, toSnap.Structs
+
+	names := make([]string, 0, len(from)+len(to))
+	for name := range from {
+		names = append(names, name)
+	}
+
+	for name := range to {
+		if _, ok := from[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	changes := make([]structChange, 0)
+
+	for _, name := range names {
+		old, hadOld := from[name]
+		s, hasNew := to[name]
+
+		switch {
+		case !hasNew:
+			changes = append(changes, structChange{Struct: name, Removed: true})
+		case !hadOld:
+			changes = append(changes, structChange{Struct: name})
+		default:
+			if oldMeta, meta := fromSnap.Meta[name], toSnap.Meta[name]; len(oldMeta) > 0 || len(meta) > 0 {
+				old = append(textra.Struct{metaField(oldMeta)}, old...)
+				s = append(textra.Struct{metaField(meta)}, s...)
+			}
+
+			for _, change := range textra.Diff(old, s) {
+				change := change
+				changes = append(changes, structChange{Struct: name, Change: &change})
+			}
+		}
+	}
+
+	return changes
+}
+
+// metaField returns struct metadata as a blank field.
+func metaField(meta textra.Tags) textra.Field {
+	if meta == nil {
+		meta = make(textra.Tags, 0)
+	}
+
+	return textra.Field{Name: "_", Type: "struct", Tags: meta}
+}
+
+// allowlist holds allowed changes: structs, fields or field tags.
+type allowlist map[string]bool
+
+func readAllowlist(path string) (allowlist, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	allow := make(allowlist)
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		allow[strings.Join(strings.Fields(line), " ")] = true
+	}
+
+	return allow, scanner.Err()
+}
+
+func (a allowlist) allows(c structChange) bool {
+	if a[c.Struct] {
+		return true
+	}
+
+	if c.Change == nil {
+		return false
+	}
+
+	field := c.Struct + "." + c.Change.Field
+
+	return a[field] || (c.Change.Tag != "" && a[field+" "+c.Change.Tag])
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/ravsii/textra"
+	"github.com/ravsii/textra/docgen"
+)
+
+func runDoc(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("doc", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	tag := fs.String("tag", "json", "a tag used for keys")
+	format := fs.String("format", "markdown", "an output format: markdown or html")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 2 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	render := docgen.Markdown
+
+	switch *format {
+	case "markdown":
+	case "html":
+		render = docgen.HTML
+	default:
+		fmt.Fprintf(stderr, "textra: unknown format %q\n", *format)
+		return 2
+	}
+
+	structs, err := textra.ExtractDir(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	out, err := render(structs, fs.Arg(1), *tag)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	fmt.Fprint(stdout, out)
+
+	return 0
+}
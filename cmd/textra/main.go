@@ -0,0 +1,61 @@
+// Command textra guards struct tags from unexpected changes and documents
+// structs.
+//
+// Usage:
+//
+//	textra snapshot ./pkg > schema.json
+//	textra check [-allow allowlist.txt] [-breaking] schema.json ./pkg
+//	textra doc [-tag json] [-format markdown|html] ./pkg Config
+//
+// snapshot records fields, tags and metadata (tags of blank "_" fields) of
+// every struct in a package.
+// check compares the package with a snapshot and exits with status 1 if
+// anything changed, printing the changes. An allowlist holds one change per
+// line, in one of the forms below. Empty lines and lines starting with "#"
+// are ignored.
+//
+//	User              any change of a struct
+//	User.Email        any change of a field
+//	User.Email json   any change of a field's tag
+//
+// Struct metadata is checked as a field named "_", like "User._ bun".
+//
+// doc renders a reference for a struct and structs it uses, see package
+// docgen.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `usage:
+  textra snapshot <dir>
+  textra check [-allow file] [-breaking] <snapshot.json> <dir>
+  textra doc [-tag name] [-format markdown|html] <dir> <struct>
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes a command and returns an exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	switch args[0] {
+	case "snapshot":
+		return runSnapshot(args[1:], stdout, stderr)
+	case "check":
+		return runCheck(args[1:], stdout, stderr)
+	case "doc":
+		return runDoc(args[1:], stdout, stderr)
+	default:
+		fmt.Fprintf(stderr, "textra: unknown command %q\n%s", args[0], usage)
+		return 2
+	}
+}
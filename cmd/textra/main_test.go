@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const modelsV1 = `package models
+
+type User struct {
+	ID   int    ` + "`json:\"id\" db:\"id\"`" + `
+	Name string ` + "`json:\"name,omitempty\"`" + `
+}
+
+type Order struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+`
+
+const modelsV2 = `package models
+
+type User struct {
+	ID    int    ` + "`json:\"user_id\" db:\"id\"`" + `
+	Name  string ` + "`json:\"name\"`" + `
+	Email string ` + "`json:\"email\"`" + `
+}
+`
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSnapshotCheck(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "textra")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pkg := filepath.Join(dir, "models")
+	if err := os.Mkdir(pkg, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile(t, filepath.Join(pkg, "models.go"), modelsV1)
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"snapshot", pkg}, &stdout, &stderr); code != 0 {
+		t.Fatalf("snapshot: code %d, stderr %s", code, stderr.String())
+	}
+
+	schema := filepath.Join(dir, "schema.json")
+	writeFile(t, schema, stdout.String())
+
+	stdout.Reset()
+	stderr.Reset()
+
+	if code := run([]string{"check", schema, pkg}, &stdout, &stderr); code != 0 || stdout.Len() != 0 {
+		t.Fatalf("check of unchanged package: code %d, stdout %s, stderr %s", code, stdout.String(), stderr.String())
+	}
+
+	writeFile(t, filepath.Join(pkg, "models.go"), modelsV2)
+
+	allow := filepath.Join(dir, "allow.txt")
+	writeFile(t, allow, "# intended\nUser.Email\n\nUser.Name  json\n")
+
+	testCases := []struct {
+		name string
+		args []string
+		code int
+		want []string
+	}{
+		{
+			name: "all changes",
+			args: []string{"check", schema, pkg},
+			code: 1,
+			want: []string{
+				`Order: struct removed [breaking]`,
+				`User.ID: tag json changed from "id" to "user_id" [breaking: json]`,
+				`User.Name: json option omitempty removed`,
+				`User.Email: field added (string)`,
+			},
+		},
+		{
+			name: "allowlist",
+			args: []string{"check", "-allow", allow, schema, pkg},
+			code: 1,
+			want: []string{
+				`Order: struct removed [breaking]`,
+				`User.ID: tag json changed from "id" to "user_id" [breaking: json]`,
+			},
+		},
+		{
+			name: "breaking only",
+			args: []string{"check", "-breaking", schema, pkg},
+			code: 1,
+			want: []string{
+				`Order: struct removed [breaking]`,
+				`User.ID: tag json changed from "id" to "user_id" [breaking: json]`,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			code := run(testCase.args, &stdout, &stderr)
+			if code != testCase.code {
+				t.Errorf("want code %d, got %d, stderr %s", testCase.code, code, stderr.String())
+			}
+
+			want := strings.Join(testCase.want, "\n") + "\n"
+			if stdout.String() != want {
+				t.Errorf("want\n%s\ngot\n%s", want, stdout.String())
+			}
+		})
+	}
+}
+
+func TestCheckMeta(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "textra")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	model := func(table string) string {
+		return "package models\n\ntype User struct {\n" +
+			"\t_  struct{} `bun:\"table:" + table + "\"`\n" +
+			"\tID int      `bun:\"id,pk\"`\n}\n"
+	}
+
+	writeFile(t, filepath.Join(dir, "models.go"), model("users"))
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"snapshot", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("snapshot: code %d, stderr %s", code, stderr.String())
+	}
+
+	if !strings.Contains(stdout.String(), `"meta"`) {
+		t.Errorf("snapshot has no metadata:\n%s", stdout.String())
+	}
+
+	schema := filepath.Join(dir, "schema.json")
+	writeFile(t, schema, stdout.String())
+	writeFile(t, filepath.Join(dir, "models.go"), model("accounts"))
+
+	stdout.Reset()
+	stderr.Reset()
+
+	code := run([]string{"check", schema, dir}, &stdout, &stderr)
+
+	want := `User._: tag bun changed from "table:users" to "table:accounts" [breaking: bun]` + "\n"
+	if code != 1 || stdout.String() != want {
+		t.Errorf("want code 1 and\n%s\ngot code %d and\n%s\nstderr %s", want, code, stdout.String(), stderr.String())
+	}
+}
+
+func TestDoc(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"doc", "-tag", "json", "-format", "html", "../../testdata/source", "Base"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("doc: code %d, stderr %s", code, stderr.String())
+	}
+
+	want := "<tr><td><code>id</code></td><td><code>int</code></td><td></td><td></td><td>ID is a primary key. It&#39;s never reused.</td></tr>"
+	if !strings.Contains(stdout.String(), want) {
+		t.Errorf("doc output doesn't contain %s:\n%s", want, stdout.String())
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := [][]string{
+		nil,
+		{"unknown"},
+		{"snapshot"},
+		{"snapshot", "does-not-exist"},
+		{"check", "schema.json"},
+		{"check", "does-not-exist.json", "."},
+		{"doc", "."},
+		{"doc", "-format", "pdf", "../../testdata/source", "Base"},
+		{"doc", "../../testdata/source", "Missing"},
+	}
+
+	for _, args := range testCases {
+		var stdout, stderr bytes.Buffer
+		if code := run(args, &stdout, &stderr); code != 2 {
+			t.Errorf("%v: want code 2, got %d", args, code)
+		}
+	}
+}
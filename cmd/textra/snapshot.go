@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/ravsii/textra"
+)
+
+func runSnapshot(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	infos, err := textra.ExtractDirInfo(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	out, err := json.MarshalIndent(textra.NewSnapshotInfo(infos), "", "  ")
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	fmt.Fprintln(stdout, string(out))
+
+	return 0
+}
+
+func readSnapshot(path string) (textra.Snapshot, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return textra.Snapshot{}, err
+	}
+
+	snap, err := textra.ParseSnapshot(b)
+	if err != nil {
+		return textra.Snapshot{}, fmt.Errorf("%s: %v", path, err)
+	}
+
+	return snap, nil
+}
@@ -0,0 +1,363 @@
+package textra
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChangeKind is a kind of a Change.
+type ChangeKind string
+
+// Possible ChangeKind values.
+const (
+	FieldAdded    ChangeKind = "field_added"
+	FieldRemoved  ChangeKind = "field_removed"
+	TypeChanged   ChangeKind = "type_changed"
+	TagAdded      ChangeKind = "tag_added"
+	TagRemoved    ChangeKind = "tag_removed"
+	TagChanged    ChangeKind = "tag_changed"
+	OptionAdded   ChangeKind = "option_added"
+	OptionRemoved ChangeKind = "option_removed"
+	OptionChanged ChangeKind = "option_changed"
+)
+
+// Change is a single difference between two Structs.
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	// Field is a name of the changed field.
+	Field string `json:"field"`
+	// Tag is a tag's name for tag and option changes.
+	Tag string `json:"tag,omitempty"`
+	// Option is an option's name for option changes.
+	Option string `json:"option,omitempty"`
+	// Old and New hold changed values: types, tags' values or options'
+	// values.
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+	// Breaking lists tag keys for which the change is breaking,
+	// like ["json", "db"].
+	Breaking []string `json:"breaking,omitempty"`
+}
+
+// Changes is a result of Diff.
+type Changes []Change
+
+// Diff compares two versions of a struct, matching fields by their names.
+//
+// Changes are breaking for a tag key if:
+//   - a field with the tag is removed and no other field took its value;
+//   - a field with the tag changes its type;
+//   - a tag's value changes, unless it was ignored ("-") or an empty value
+//     is replaced with the field's name or vice versa;
+//   - a tag is added or removed, and the value differs from the field's name
+//     (which encoding/json uses by default, an empty value means the same),
+//     or its options are breaking as if they were added or removed;
+//   - an option is added, removed or changed, except a removed "omitempty".
+//
+// Added fields are never breaking.
+func Diff(from, to Struct) Changes {
+	changes := make(Changes, 0)
+
+	for _, old := range from {
+		field, ok := to.Field(old.Name)
+		if !ok {
+			changes = append(changes, Change{
+				Kind:     FieldRemoved,
+				Field:    old.Name,
+				Old:      old.Type,
+				Breaking: removedBreaking(old, to),
+			})
+
+			continue
+		}
+
+		if old.Type != field.Type {
+			changes = append(changes, Change{
+				Kind:     TypeChanged,
+				Field:    old.Name,
+				Old:      old.Type,
+				New:      field.Type,
+				Breaking: exposedKeys(old.Tags, field.Tags),
+			})
+		}
+
+		changes = append(changes, diffTags(old, field)...)
+	}
+
+	for _, field := range to {
+		if _, ok := from.Field(field.Name); !ok {
+			changes = append(changes, Change{Kind: FieldAdded, Field: field.Name, New: field.Type})
+		}
+	}
+
+	return changes
+}
+
+func diffTags(old, field Field) Changes {
+	changes := make(Changes, 0)
+
+	for _, oldTag := range old.Tags {
+		tag, ok := field.Tags.ByName(oldTag.Tag)
+		if !ok {
+			changes = append(changes, Change{
+				Kind:     TagRemoved,
+				Field:    old.Name,
+				Tag:      oldTag.Tag,
+				Old:      oldTag.Raw(),
+				Breaking: breakingIf(!oldTag.Ignored() && tagBreaking(old.Name, oldTag, Tag{Tag: oldTag.Tag}), oldTag.Tag),
+			})
+
+			continue
+		}
+
+		if oldTag.Value != tag.Value {
+			changes = append(changes, Change{
+				Kind:     TagChanged,
+				Field:    old.Name,
+				Tag:      tag.Tag,
+				Old:      oldTag.Value,
+				New:      tag.Value,
+				Breaking: breakingIf(!oldTag.Ignored() && tagName(oldTag, old.Name) != tagName(tag, field.Name), tag.Tag),
+			})
+		}
+
+		changes = append(changes, diffOptions(old.Name, oldTag, tag)...)
+	}
+
+	for _, tag := range field.Tags {
+		if _, ok := old.Tags.ByName(tag.Tag); !ok {
+			changes = append(changes, Change{
+				Kind:     TagAdded,
+				Field:    field.Name,
+				Tag:      tag.Tag,
+				New:      tag.Raw(),
+				Breaking: breakingIf(tag.Ignored() || tagBreaking(field.Name, Tag{Tag: tag.Tag}, tag), tag.Tag),
+			})
+		}
+	}
+
+	return changes
+}
+
+// tagName returns a name the tag gives to the field, an empty value falls
+// back to the field's name, like encoding/json does.
+func tagName(tag Tag, field string) string {
+	if tag.Value == "" {
+		return field
+	}
+
+	return tag.Value
+}
+
+// tagBreaking reports whether replacing old with tag is breaking: the name
+// changes or options change in a breaking way. It's used for added and
+// removed tags, where one of them is an empty Tag.
+func tagBreaking(field string, old, tag Tag) bool {
+	if tagName(old, field) != tagName(tag, field) {
+		return true
+	}
+
+	return len(diffOptions(field, old, tag).Breaking(tag.Tag)) > 0
+}
+
+func diffOptions(field string, old, tag Tag) Changes {
+	changes := make(Changes, 0)
+	oldOptions, options := old.Options(), tag.Options()
+
+	seen := make(map[string]bool)
+
+	for _, opt := range old.Optional {
+		name, oldValue := splitOption(opt)
+		if name == "" || seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		value, ok := options[name]
+
+		switch {
+		case !ok:
+			changes = append(changes, Change{
+				Kind:     OptionRemoved,
+				Field:    field,
+				Tag:      tag.Tag,
+				Option:   name,
+				Old:      oldValue,
+				Breaking: breakingIf(name != "omitempty", tag.Tag),
+			})
+		case value != oldValue:
+			changes = append(changes, Change{
+				Kind:     OptionChanged,
+				Field:    field,
+				Tag:      tag.Tag,
+				Option:   name,
+				Old:      oldValue,
+				New:      value,
+				Breaking: []string{tag.Tag},
+			})
+		}
+	}
+
+	for _, opt := range tag.Optional {
+		name, value := splitOption(opt)
+		if _, ok := oldOptions[name]; !ok && name != "" && !seen[name] {
+			seen[name] = true
+			changes = append(changes, Change{
+				Kind:     OptionAdded,
+				Field:    field,
+				Tag:      tag.Tag,
+				Option:   name,
+				New:      value,
+				Breaking: []string{tag.Tag},
+			})
+		}
+	}
+
+	return changes
+}
+
+// removedBreaking returns keys of the removed field's tags, unless another
+// field of to has the same tag value.
+func removedBreaking(old Field, to Struct) []string {
+	var keys []string
+
+	for _, tag := range old.Tags {
+		if tag.Ignored() {
+			continue
+		}
+
+		taken := false
+
+		for _, field := range to {
+			if t, ok := field.Tags.ByName(tag.Tag); ok && t.Value == tag.Value {
+				taken = true
+				break
+			}
+		}
+
+		if !taken {
+			keys = append(keys, tag.Tag)
+		}
+	}
+
+	return keys
+}
+
+// exposedKeys returns unique keys of non-ignored tags from both lists.
+func exposedKeys(lists ...Tags) []string {
+	var keys []string
+
+	seen := make(map[string]bool)
+
+	for _, tags := range lists {
+		for _, tag := range tags {
+			if !tag.Ignored() && !seen[tag.Tag] {
+				seen[tag.Tag] = true
+				keys = append(keys, tag.Tag)
+			}
+		}
+	}
+
+	return keys
+}
+
+func breakingIf(cond bool, key string) []string {
+	if cond {
+		return []string{key}
+	}
+
+	return nil
+}
+
+// IsBreaking returns true if the change is breaking for the given tag key.
+func (c Change) IsBreaking(key string) bool {
+	for _, k := range c.Breaking {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c Change) String() string {
+	var s string
+
+	switch c.Kind {
+	case FieldAdded:
+		s = fmt.Sprintf("%s: field added (%s)", c.Field, c.New)
+	case FieldRemoved:
+		s = fmt.Sprintf("%s: field removed (%s)", c.Field, c.Old)
+	case TypeChanged:
+		s = fmt.Sprintf("%s: type changed from %s to %s", c.Field, c.Old, c.New)
+	case TagAdded:
+		s = fmt.Sprintf("%s: tag %s:%q added", c.Field, c.Tag, c.New)
+	case TagRemoved:
+		s = fmt.Sprintf("%s: tag %s:%q removed", c.Field, c.Tag, c.Old)
+	case TagChanged:
+		s = fmt.Sprintf("%s: tag %s changed from %q to %q", c.Field, c.Tag, c.Old, c.New)
+	case OptionAdded:
+		s = fmt.Sprintf("%s: %s option %s added", c.Field, c.Tag, formatOption(c.Option, c.New))
+	case OptionRemoved:
+		s = fmt.Sprintf("%s: %s option %s removed", c.Field, c.Tag, formatOption(c.Option, c.Old))
+	case OptionChanged:
+		s = fmt.Sprintf("%s: %s option %s changed from %q to %q", c.Field, c.Tag, c.Option, c.Old, c.New)
+	default:
+		s = fmt.Sprintf("%s: %s", c.Field, c.Kind)
+	}
+
+	if len(c.Breaking) > 0 {
+		s += " [breaking: " + strings.Join(c.Breaking, ", ") + "]"
+	}
+
+	return s
+}
+
+func formatOption(name, value string) string {
+	if value == "" {
+		return name
+	}
+
+	return name + "=" + value
+}
+
+// Breaking returns changes which are breaking for the given tag key.
+func (c Changes) Breaking(key string) Changes {
+	filtered := make(Changes, 0)
+
+	for _, change := range c {
+		if change.IsBreaking(key) {
+			filtered = append(filtered, change)
+		}
+	}
+
+	return filtered
+}
+
+// BreakingKeys returns all tag keys which have breaking changes, in order of
+// their first appearance.
+func (c Changes) BreakingKeys() []string {
+	var keys []string
+
+	seen := make(map[string]bool)
+
+	for _, change := range c {
+		for _, key := range change.Breaking {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return keys
+}
+
+func (c Changes) String() string {
+	lines := make([]string, 0, len(c))
+	for _, change := range c {
+		lines = append(lines, change.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
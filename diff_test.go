@@ -0,0 +1,128 @@
+package textra_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ravsii/textra"
+)
+
+func TestDiff(t *testing.T) {
+	type Old struct {
+		ID      int    `db:"id,pk"          json:"id"`
+		Name    string `json:"name,omitempty"`
+		Email   string `json:"email"`
+		Age     int    `json:"age"`
+		Hidden  string `json:"-"`
+		Removed string `db:"removed"        json:"removed"`
+		Moved   string `json:"moved"`
+		Plain   string
+	}
+
+	type New struct {
+		ID      int64  `db:"id,pk"            json:"id,string"`
+		Name    string `json:"name"`
+		Email   string `json:"mail"`
+		Age     int    `json:"age,omitempty"`
+		Hidden  string `json:"hidden"`
+		Renamed string `json:"moved"`
+		Plain   string `json:"plain"`
+		Added   string `json:"added"`
+	}
+
+	changes := textra.Diff(textra.Extract((*Old)(nil)), textra.Extract((*New)(nil)))
+
+	want := textra.Changes{
+		{Kind: textra.TypeChanged, Field: "ID", Old: "int", New: "int64", Breaking: []string{"db", "json"}},
+		{Kind: textra.OptionAdded, Field: "ID", Tag: "json", Option: "string", Breaking: []string{"json"}},
+		{Kind: textra.OptionRemoved, Field: "Name", Tag: "json", Option: "omitempty"},
+		{Kind: textra.TagChanged, Field: "Email", Tag: "json", Old: "email", New: "mail", Breaking: []string{"json"}},
+		{Kind: textra.OptionAdded, Field: "Age", Tag: "json", Option: "omitempty", Breaking: []string{"json"}},
+		{Kind: textra.TagChanged, Field: "Hidden", Tag: "json", Old: "-", New: "hidden"},
+		{Kind: textra.FieldRemoved, Field: "Removed", Old: "string", Breaking: []string{"db", "json"}},
+		{Kind: textra.FieldRemoved, Field: "Moved", Old: "string"},
+		{Kind: textra.TagAdded, Field: "Plain", Tag: "json", New: "plain", Breaking: []string{"json"}},
+		{Kind: textra.FieldAdded, Field: "Renamed", New: "string"},
+		{Kind: textra.FieldAdded, Field: "Added", New: "string"},
+	}
+
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Diff() =\n%s\nwant\n%s", changes, want)
+	}
+
+	if keys := changes.BreakingKeys(); !reflect.DeepEqual(keys, []string{"db", "json"}) {
+		t.Errorf("BreakingKeys() = %v", keys)
+	}
+
+	if db := changes.Breaking("db"); len(db) != 2 {
+		t.Errorf("Breaking(db) = %v, want 2 changes", db)
+	}
+
+	wantStr := `Email: tag json changed from "email" to "mail" [breaking: json]`
+	if got := changes[3].String(); got != wantStr {
+		t.Errorf("String() = %s, want %s", got, wantStr)
+	}
+
+	if same := textra.Diff(textra.Extract((*Old)(nil)), textra.Extract((*Old)(nil))); len(same) != 0 {
+		t.Errorf("Diff() of equal structs = %v", same)
+	}
+}
+
+func TestDiff_EmptyTagValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new textra.Struct
+		breaking bool
+	}{
+		{
+			name:     "removed omitempty only",
+			old:      textra.Struct{{Name: "Name", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "", Optional: []string{"omitempty"}}}}},
+			new:      textra.Struct{{Name: "Name", Type: "string", Tags: textra.Tags{}}},
+			breaking: false,
+		},
+		{
+			name:     "removed string option",
+			old:      textra.Struct{{Name: "ID", Type: "int", Tags: textra.Tags{{Tag: "json", Value: "", Optional: []string{"string"}}}}},
+			new:      textra.Struct{{Name: "ID", Type: "int", Tags: textra.Tags{}}},
+			breaking: true,
+		},
+		{
+			name:     "empty value to field name",
+			old:      textra.Struct{{Name: "Name", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "", Optional: []string{"omitempty"}}}}},
+			new:      textra.Struct{{Name: "Name", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "Name", Optional: []string{"omitempty"}}}}},
+			breaking: false,
+		},
+		{
+			name:     "field name to empty value",
+			old:      textra.Struct{{Name: "Name", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "Name"}}}},
+			new:      textra.Struct{{Name: "Name", Type: "string", Tags: textra.Tags{{Tag: "json", Value: ""}}}},
+			breaking: false,
+		},
+		{
+			name:     "empty value to another name",
+			old:      textra.Struct{{Name: "Name", Type: "string", Tags: textra.Tags{{Tag: "json", Value: ""}}}},
+			new:      textra.Struct{{Name: "Name", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "name"}}}},
+			breaking: true,
+		},
+		{
+			name:     "added string option",
+			old:      textra.Struct{{Name: "ID", Type: "int", Tags: textra.Tags{}}},
+			new:      textra.Struct{{Name: "ID", Type: "int", Tags: textra.Tags{{Tag: "json", Value: "", Optional: []string{"string"}}}}},
+			breaking: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			changes := textra.Diff(tt.old, tt.new)
+			if len(changes) == 0 {
+				t.Fatal("Diff() found no changes")
+			}
+
+			if got := len(changes.Breaking("json")) > 0; got != tt.breaking {
+				t.Errorf("Diff() = %s, want breaking %v", changes, tt.breaking)
+			}
+		})
+	}
+}
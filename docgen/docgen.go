@@ -0,0 +1,307 @@
+// Package docgen renders reference documentation for structs, like
+// configuration structs with env or yaml tags, as Markdown or HTML tables.
+//
+// Every field becomes a row with a key, a Go type, a default value, whether
+// it's required and a description. Keys come from the chosen tag, fields
+// without it use their Go names, ignored ("-") and unexported fields are
+// skipped. Defaults come from a default or envDefault tag, or from a
+// default= option of the key tag. A field is required if the key tag has a
+// required option, a validate tag has a required check or a required tag is
+// "true". Descriptions come from textra.Field.Description.
+//
+// Fields of struct types found in the set of structs get their own sections,
+// linked by anchors. Embedded structs without a key are inlined.
+package docgen
+
+import (
+	"fmt"
+	"html"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ravsii/textra"
+	"github.com/ravsii/textra/internal/typestr"
+)
+
+// Collect extracts values (structs or pointers to structs) and all struct
+// types they use, keyed by type names without packages. Its result can be
+// used instead of textra.ExtractDir, but fields don't have doc comments.
+func Collect(values ...interface{}) map[string]textra.Struct {
+	structs := make(map[string]textra.Struct)
+	for _, v := range values {
+		collect(reflect.TypeOf(v), structs)
+	}
+
+	return structs
+}
+
+func collect(typ reflect.Type, structs map[string]textra.Struct) {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice ||
+		typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct || typ.Name() == "" {
+		return
+	}
+
+	if _, ok := structs[typ.Name()]; ok {
+		return
+	}
+
+	s := textra.Extract(reflect.Zero(reflect.PtrTo(typ)).Interface())
+	structs[typ.Name()] = s
+
+	for i := 0; i < typ.NumField(); i++ {
+		collect(typ.Field(i).Type, structs)
+	}
+}
+
+// Markdown renders root and structs it uses as Markdown tables, with keys
+// from the tag.
+func Markdown(structs map[string]textra.Struct, root, tag string) (string, error) {
+	sections, err := build(structs, root, tag)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+
+	for i, s := range sections {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+
+		fmt.Fprintf(&sb, "<a id=\"%s\"></a>\n\n## %s\n\n", s.anchor, s.name)
+		sb.WriteString("| Key | Type | Default | Required | Description |\n")
+		sb.WriteString("| --- | --- | --- | --- | --- |\n")
+
+		for _, r := range s.rows {
+			typ := "`" + r.typ + "`"
+			if r.link != "" {
+				typ = "[" + typ + "](#" + r.link + ")"
+			}
+
+			def := ""
+			if r.def != "" {
+				def = "`" + r.def + "`"
+			}
+
+			fmt.Fprintf(&sb, "| `%s` | %s | %s | %s | %s |\n",
+				r.key, escapeMarkdown(typ), escapeMarkdown(def), required(r.required), escapeMarkdown(r.desc))
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// HTML renders root and structs it uses as HTML tables, with keys from the
+// tag.
+func HTML(structs map[string]textra.Struct, root, tag string) (string, error) {
+	sections, err := build(structs, root, tag)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+
+	for _, s := range sections {
+		fmt.Fprintf(&sb, "<h2 id=\"%s\">%s</h2>\n", s.anchor, html.EscapeString(s.name))
+		sb.WriteString("<table>\n<thead>\n<tr><th>Key</th><th>Type</th><th>Default</th><th>Required</th><th>Description</th></tr>\n</thead>\n<tbody>\n")
+
+		for _, r := range s.rows {
+			typ := "<code>" + html.EscapeString(r.typ) + "</code>"
+			if r.link != "" {
+				typ = "<a href=\"#" + r.link + "\">" + typ + "</a>"
+			}
+
+			def := ""
+			if r.def != "" {
+				def = "<code>" + html.EscapeString(r.def) + "</code>"
+			}
+
+			fmt.Fprintf(&sb, "<tr><td><code>%s</code></td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+				html.EscapeString(r.key), typ, def, required(r.required), html.EscapeString(r.desc))
+		}
+
+		sb.WriteString("</tbody>\n</table>\n")
+	}
+
+	return sb.String(), nil
+}
+
+// arrayLength matches a length of an array type, like "[2]" or "[N]".
+var arrayLength = regexp.MustCompile(`^\[[^\]]+\]`)
+
+type section struct {
+	name   string
+	anchor string
+	rows   []row
+}
+
+type row struct {
+	key      string
+	typ      string
+	link     string
+	def      string
+	required bool
+	desc     string
+}
+
+// build returns sections for root and all structs it uses, in order of
+// their first use.
+func build(structs map[string]textra.Struct, root, tag string) ([]section, error) {
+	if _, ok := structs[root]; !ok {
+		return nil, fmt.Errorf("docgen: struct %s not found", root)
+	}
+
+	queue := []string{root}
+	seen := map[string]bool{root: true}
+	sections := make([]section, 0)
+
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		s := section{name: name, anchor: anchor(name)}
+		s.rows = rows(structs, structs[name], tag, map[string]bool{name: true}, func(nested string) {
+			if !seen[nested] {
+				seen[nested] = true
+				queue = append(queue, nested)
+			}
+		})
+
+		sections = append(sections, s)
+	}
+
+	return sections, nil
+}
+
+// rows returns rows of s. Embedded structs without a key are inlined,
+// inlined holds their names to stop on cycles. use is called for every
+// nested struct.
+func rows(structs map[string]textra.Struct, s textra.Struct, tag string, inlined map[string]bool, use func(string)) []row {
+	result := make([]row, 0, len(s))
+
+	for _, field := range s {
+		if !isExported(field.Name) && !field.Embedded {
+			continue
+		}
+
+		key := field.Name
+		t, hasTag := field.Tags.ByName(tag)
+
+		if hasTag && t.Ignored() {
+			continue
+		}
+
+		if hasTag && t.Value != "" {
+			key = t.Value
+		}
+
+		nested := nestedStruct(structs, field.Type)
+
+		if field.Embedded && (!hasTag || t.Value == "") && nested != "" && !inlined[nested] {
+			inlined[nested] = true
+			result = append(result, rows(structs, structs[nested], tag, inlined, use)...)
+			delete(inlined, nested)
+
+			continue
+		}
+
+		r := row{
+			key:      key,
+			typ:      field.Type,
+			def:      defaultValue(field, t),
+			required: isRequired(field, t),
+			desc:     strings.Join(strings.Fields(field.Description()), " "),
+		}
+
+		if nested != "" {
+			r.link = anchor(nested)
+			use(nested)
+		}
+
+		result = append(result, r)
+	}
+
+	return result
+}
+
+// nestedStruct returns a name of a struct from structs used by a type,
+// through pointers, slices, arrays and maps.
+func nestedStruct(structs map[string]textra.Struct, typ string) string {
+	t := typestr.Parse(typ)
+	for {
+		if t.Kind != typestr.Named {
+			t = t.Elem
+			continue
+		}
+
+		// typestr doesn't know arrays, like "[2]Item", strip their lengths
+		// the same way slices are handled.
+		length := arrayLength.FindString(t.Name)
+		if length == "" {
+			break
+		}
+
+		t = typestr.Parse(t.Name[len(length):])
+	}
+
+	if _, ok := structs[t.BaseName()]; ok {
+		return t.BaseName()
+	}
+
+	return ""
+}
+
+func defaultValue(field textra.Field, key textra.Tag) string {
+	for _, name := range []string{"default", "envDefault"} {
+		if t, ok := field.Tags.ByName(name); ok {
+			return t.Raw()
+		}
+	}
+
+	v, _ := key.Option("default")
+
+	return v
+}
+
+func isRequired(field textra.Field, key textra.Tag) bool {
+	if key.HasOption("required") {
+		return true
+	}
+
+	if t, ok := field.Tags.ByName("validate"); ok && (t.Value == "required" || t.HasOption("required")) {
+		return true
+	}
+
+	t, ok := field.Tags.ByName("required")
+
+	return ok && t.Value == "true"
+}
+
+func required(ok bool) string {
+	if ok {
+		return "yes"
+	}
+
+	return ""
+}
+
+// anchor returns an anchor for a section, like "database".
+func anchor(name string) string {
+	return strings.ToLower(name)
+}
+
+func escapeMarkdown(s string) string {
+	return strings.Replace(s, "|", "\\|", -1)
+}
+
+func isExported(name string) bool {
+	r, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(r)
+}
@@ -0,0 +1,112 @@
+package docgen_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ravsii/textra"
+	"github.com/ravsii/textra/docgen"
+)
+
+type Common struct {
+	Debug bool     `env:"DEBUG" envDefault:"false" desc:"Enables | debug logs"`
+	Hosts []string `env:"HOSTS" envDefault:"a, b"`
+}
+
+type Database struct {
+	Host string `env:"HOST,required"`
+	Next *Database
+}
+
+type Config struct {
+	Common
+	Port     int        `env:"PORT,default=8080" validate:"required"`
+	DB       Database   `env:"DB"`
+	Replicas []Database `env:"REPLICAS"`
+	Secret   string     `env:"-"`
+	internal string
+}
+
+func TestMarkdown(t *testing.T) {
+	structs := docgen.Collect((*Config)(nil))
+
+	got, err := docgen.Markdown(structs, "Config", "env")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "<a id=\"config\"></a>\n\n## Config\n\n" +
+		"| Key | Type | Default | Required | Description |\n" +
+		"| --- | --- | --- | --- | --- |\n" +
+		"| `DEBUG` | `bool` | `false` |  | Enables \\| debug logs |\n" +
+		"| `HOSTS` | `[]string` | `a, b` |  |  |\n" +
+		"| `PORT` | `int` | `8080` | yes |  |\n" +
+		"| `DB` | [`github.com/ravsii/textra/docgen_test.Database`](#database) |  |  |  |\n" +
+		"| `REPLICAS` | [`[]docgen_test.Database`](#database) |  |  |  |\n" +
+		"\n<a id=\"database\"></a>\n\n## Database\n\n" +
+		"| Key | Type | Default | Required | Description |\n" +
+		"| --- | --- | --- | --- | --- |\n" +
+		"| `HOST` | `string` |  | yes |  |\n" +
+		"| `Next` | [`*docgen_test.Database`](#database) |  |  |  |\n"
+
+	if got != want {
+		t.Errorf("Markdown() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMarkdown_Arrays(t *testing.T) {
+	type Item struct {
+		Name string `json:"name"`
+	}
+
+	type Order struct {
+		Items [2]Item `json:"items"`
+	}
+
+	fromSource := map[string]textra.Struct{
+		"Order": {{Name: "Items", Type: "[N]*Item", Tags: textra.Tags{{Tag: "json", Value: "items"}}}},
+		"Item":  {{Name: "Name", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "name"}}}},
+	}
+
+	for name, structs := range map[string]map[string]textra.Struct{
+		"source":  fromSource,
+		"collect": docgen.Collect(Order{}),
+	} {
+		got, err := docgen.Markdown(structs, "Order", "json")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(got, "](#item) |") || !strings.Contains(got, "## Item\n") {
+			t.Errorf("%s: Markdown() doesn't link the array element:\n%s", name, got)
+		}
+	}
+}
+
+func TestHTML(t *testing.T) {
+	structs := map[string]textra.Struct{
+		"Config": {
+			{Name: "Name", Type: "string", Tags: textra.Tags{{Tag: "yaml", Value: "name"}, {Tag: "default", Value: "<none>"}}, Doc: "Name of\nthe app."},
+		},
+	}
+
+	got, err := docgen.HTML(structs, "Config", "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "<h2 id=\"config\">Config</h2>\n" +
+		"<table>\n<thead>\n<tr><th>Key</th><th>Type</th><th>Default</th><th>Required</th><th>Description</th></tr>\n</thead>\n<tbody>\n" +
+		"<tr><td><code>name</code></td><td><code>string</code></td><td><code>&lt;none&gt;</code></td><td></td><td>Name of the app.</td></tr>\n" +
+		"</tbody>\n</table>\n"
+
+	if got != want {
+		t.Errorf("HTML() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMarkdown_NotFound(t *testing.T) {
+	if _, err := docgen.Markdown(map[string]textra.Struct{}, "Config", "env"); err == nil {
+		t.Error("Markdown() error = nil")
+	}
+}
@@ -0,0 +1,303 @@
+package textra
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SnapshotVersion is a version of the JSON representation written by
+// NewSnapshot. It's increased on every incompatible change of the format.
+//
+// Version 1 looks like this:
+//
+//	{
+//	  "version": 1,
+//	  "structs": {
+//	    "User": [
+//	      {
+//	        "name": "ID",
+//	        "type": "int",
+//	        "tags": [{"tag": "json", "value": "id", "optional": ["omitempty"]}],
+//	        "embedded": false,
+//	        "doc": "ID is a primary key.",
+//	        "comment": ""
+//	      }
+//	    ]
+//	  },
+//	  "meta": {
+//	    "User": [{"tag": "bun", "value": "table:users"}]
+//	  }
+//	}
+//
+// A Struct is an array of fields in declaration order. "tags", "optional",
+// "embedded", "doc" and "comment" are omitted if empty, so is "text" of a
+// tag, see Tag.Text. "meta" holds tags of blank (_) fields and has only
+// structs with metadata, it's omitted if there are none. Unknown keys are
+// ignored.
+const SnapshotVersion = 1
+
+// Snapshot is a versioned JSON envelope for a set of structs, keyed by
+// their names.
+type Snapshot struct {
+	Version int               `json:"version"`
+	Structs map[string]Struct `json:"structs"`
+	// Meta holds struct metadata, see StructInfo.Meta.
+	Meta map[string]Tags `json:"meta,omitempty"`
+}
+
+// NewSnapshot returns a Snapshot of the current version.
+func NewSnapshot(structs map[string]Struct) Snapshot {
+	if structs == nil {
+		structs = make(map[string]Struct)
+	}
+
+	return Snapshot{Version: SnapshotVersion, Structs: structs}
+}
+
+// NewSnapshotInfo is like NewSnapshot, but also records struct metadata.
+func NewSnapshotInfo(infos map[string]StructInfo) Snapshot {
+	snap := NewSnapshot(make(map[string]Struct, len(infos)))
+
+	for name, info := range infos {
+		snap.Structs[name] = info.Fields
+
+		if len(info.Meta) > 0 {
+			if snap.Meta == nil {
+				snap.Meta = make(map[string]Tags)
+			}
+
+			snap.Meta[name] = info.Meta
+		}
+	}
+
+	return snap
+}
+
+// ParseSnapshot decodes a Snapshot from JSON. It returns an error if the
+// snapshot has a version other than SnapshotVersion.
+func ParseSnapshot(data []byte) (Snapshot, error) {
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return Snapshot{}, fmt.Errorf("textra: can't decode snapshot: %v", err)
+	}
+
+	if snap.Version != SnapshotVersion {
+		return Snapshot{}, fmt.Errorf("textra: unsupported snapshot version %d, want %d", snap.Version, SnapshotVersion)
+	}
+
+	if snap.Structs == nil {
+		snap.Structs = make(map[string]Struct)
+	}
+
+	return snap, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A tag must have a name,
+// empty options are decoded as nil, just like Extract does.
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	type plain Tag
+
+	var tag plain
+	if err := json.Unmarshal(data, &tag); err != nil {
+		return err
+	}
+
+	if tag.Tag == "" {
+		return errors.New("textra: tag without a name")
+	}
+
+	if len(tag.Optional) == 0 {
+		tag.Optional = nil
+	}
+
+	*t = Tag(tag)
+
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A field must have a name,
+// missing tags are decoded as empty Tags, just like Extract does.
+func (f *Field) UnmarshalJSON(data []byte) error {
+	type plain Field
+
+	var field plain
+	if err := json.Unmarshal(data, &field); err != nil {
+		return err
+	}
+
+	if field.Name == "" {
+		return errors.New("textra: field without a name")
+	}
+
+	if field.Tags == nil {
+		field.Tags = make(Tags, 0)
+	}
+
+	*f = Field(field)
+
+	return nil
+}
+
+// ParseTags parses the output of Tags.String, like
+//
+//	[json:"id,omitempty" db:"id"]
+func ParseTags(s string) (Tags, error) {
+	tags, rest, err := parseTagList(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if rest != "" {
+		return nil, fmt.Errorf("textra: unexpected %q after tags", rest)
+	}
+
+	return tags, nil
+}
+
+// ParseField parses the output of Field.String, like
+//
+//	ID(int):[json:"id,omitempty"]
+//
+// Embedded, Doc and Comment aren't a part of the text form and are always
+// empty.
+func ParseField(s string) (Field, error) {
+	field, rest, err := parseField(s)
+	if err != nil {
+		return Field{}, err
+	}
+
+	if rest != "" {
+		return Field{}, fmt.Errorf("textra: unexpected %q after field %s", rest, field.Name)
+	}
+
+	return field, nil
+}
+
+// ParseStruct parses the output of Struct.String, which is a concatenation
+// of Field.String of every field.
+func ParseStruct(s string) (Struct, error) {
+	parsed := make(Struct, 0)
+
+	for s != "" {
+		field, rest, err := parseField(s)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed = append(parsed, field)
+		s = rest
+	}
+
+	return parsed, nil
+}
+
+// parseField parses a field from the beginning of s and returns the rest.
+func parseField(s string) (Field, string, error) {
+	open := strings.IndexByte(s, '(')
+	if open <= 0 {
+		return Field{}, "", fmt.Errorf("textra: can't parse field %q: missing name or type", s)
+	}
+
+	name := s[:open]
+
+	// Types may have parentheses too, like "func(int) string".
+	depth, end := 0, -1
+
+	for i := open; i < len(s) && end < 0; i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				end = i
+			}
+		}
+	}
+
+	if end < 0 {
+		return Field{}, "", fmt.Errorf("textra: can't parse field %s: unclosed type", name)
+	}
+
+	rest := s[end+1:]
+	if !strings.HasPrefix(rest, ":") {
+		return Field{}, "", fmt.Errorf("textra: can't parse field %s: missing \":\" after type", name)
+	}
+
+	tags, rest, err := parseTagList(rest[1:])
+	if err != nil {
+		return Field{}, "", fmt.Errorf("textra: can't parse field %s: %v", name, err)
+	}
+
+	return Field{Name: name, Type: s[open+1 : end], Tags: tags}, rest, nil
+}
+
+// parseTagList parses a list of tags from the beginning of s and returns
+// the rest.
+func parseTagList(s string) (Tags, string, error) {
+	if !strings.HasPrefix(s, "[") {
+		return nil, "", errors.New("textra: tags must start with \"[\"")
+	}
+
+	s = s[1:]
+	tags := make(Tags, 0)
+
+	for {
+		if strings.HasPrefix(s, "]") {
+			return tags, s[1:], nil
+		}
+
+		if len(tags) > 0 {
+			if !strings.HasPrefix(s, " ") {
+				return nil, "", fmt.Errorf("textra: tags must be separated by a space at %q", s)
+			}
+
+			s = s[1:]
+		}
+
+		colon := strings.IndexByte(s, ':')
+		if colon <= 0 || strings.ContainsAny(s[:colon], " \"]") {
+			return nil, "", fmt.Errorf("textra: missing tag name at %q", s)
+		}
+
+		name := s[:colon]
+
+		quoted, err := quotedPrefix(s[colon+1:])
+		if err != nil {
+			return nil, "", fmt.Errorf("textra: tag %s: %v", name, err)
+		}
+
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, "", fmt.Errorf("textra: tag %s: %v", name, err)
+		}
+
+		// Tags.String drops spaces around commas, so Text is never set.
+		tag := newTag(name, value)
+		tag.Text = ""
+
+		tags = append(tags, tag)
+		s = s[colon+1+len(quoted):]
+	}
+}
+
+// quotedPrefix returns a double-quoted string at the beginning of s.
+func quotedPrefix(s string) (string, error) {
+	if !strings.HasPrefix(s, `"`) {
+		return "", errors.New("value must be quoted")
+	}
+
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return s[:i+1], nil
+		}
+	}
+
+	return "", errors.New("unterminated value")
+}
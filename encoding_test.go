@@ -0,0 +1,167 @@
+package textra_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ravsii/textra"
+)
+
+type roundTrip struct {
+	ID       int                       `json:"id,omitempty" db:"id,pk"`
+	Name     string                    `json:"name" gorm:"column:name;type:varchar(64)"`
+	Quoted   string                    `json:"quoted" validate:"oneof='a b' \\,"`
+	Handler  func(int) (string, error) `json:"-"`
+	Born     *time.Time                `sql:"born"`
+	Settings map[string][]int
+}
+
+func TestStruct_TextRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	s := textra.Extract((*roundTrip)(nil))
+
+	parsed, err := textra.ParseStruct(s.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(parsed, s) {
+		t.Errorf("ParseStruct() = %v, want %v", parsed, s)
+	}
+
+	for _, field := range s {
+		parsed, err := textra.ParseField(field.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(parsed, field) {
+			t.Errorf("ParseField() = %#v, want %#v", parsed, field)
+		}
+	}
+}
+
+func TestStruct_JSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	s := textra.Extract((*roundTrip)(nil))
+
+	b, err := json.Marshal(textra.NewSnapshot(map[string]textra.Struct{"roundTrip": s}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := textra.ParseSnapshot(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(snap.Structs["roundTrip"], s) {
+		t.Errorf("ParseSnapshot() = %v, want %v", snap.Structs["roundTrip"], s)
+	}
+}
+
+func TestSnapshot_Schema(t *testing.T) {
+	t.Parallel()
+
+	s := textra.Struct{
+		{Name: "ID", Type: "int", Tags: textra.Tags{{Tag: "json", Value: "id", Optional: []string{"omitempty"}}}},
+		{Name: "Base", Type: "Base", Tags: textra.Tags{}, Embedded: true},
+		{Name: "Bio", Type: "string", Tags: textra.Tags{{Tag: "desc", Value: "a", Optional: []string{"b"}, Text: "a, b"}}},
+	}
+
+	b, err := json.Marshal(textra.NewSnapshot(map[string]textra.Struct{"User": s}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"version":1,"structs":{"User":[` +
+		`{"name":"ID","type":"int","tags":[{"tag":"json","value":"id","optional":["omitempty"]}]},` +
+		`{"name":"Base","type":"Base","embedded":true},` +
+		`{"name":"Bio","type":"string","tags":[{"tag":"desc","value":"a","optional":["b"],"text":"a, b"}]}]}}`
+	if string(b) != want {
+		t.Errorf("json.Marshal() = %s, want %s", b, want)
+	}
+}
+
+func TestParseSnapshot_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{"invalid json", `{`},
+		{"missing version", `{"structs":{}}`},
+		{"future version", `{"version":2,"structs":{}}`},
+		{"field without a name", `{"version":1,"structs":{"A":[{"type":"int"}]}}`},
+		{"tag without a name", `{"version":1,"structs":{"A":[{"name":"A","tags":[{"value":"a"}]}]}}`},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := textra.ParseSnapshot([]byte(testCase.data)); err == nil {
+				t.Error("ParseSnapshot() error = nil")
+			}
+		})
+	}
+}
+
+func TestParseField(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		str     string
+		want    textra.Field
+		wantErr bool
+	}{
+		{
+			name: "no tags",
+			str:  `ID(int):[]`,
+			want: textra.Field{Name: "ID", Type: "int", Tags: textra.Tags{}},
+		},
+		{
+			name: "options and escapes",
+			str:  `Name(*string):[json:"name, omitempty" db:"a\"b"]`,
+			want: textra.Field{Name: "Name", Type: "*string", Tags: textra.Tags{
+				{Tag: "json", Value: "name", Optional: []string{"omitempty"}},
+				{Tag: "db", Value: `a"b`},
+			}},
+		},
+		{
+			name: "func type",
+			str:  `Fn(func(int) string):[]`,
+			want: textra.Field{Name: "Fn", Type: "func(int) string", Tags: textra.Tags{}},
+		},
+		{name: "missing type", str: `ID:[]`, wantErr: true},
+		{name: "unclosed type", str: `ID(int:[]`, wantErr: true},
+		{name: "missing tags", str: `ID(int)`, wantErr: true},
+		{name: "unquoted value", str: `ID(int):[json:id]`, wantErr: true},
+		{name: "unterminated value", str: `ID(int):[json:"id]`, wantErr: true},
+		{name: "missing separator", str: `ID(int):[json:"id"db:"id"]`, wantErr: true},
+		{name: "trailing data", str: `ID(int):[]x`, wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := textra.ParseField(testCase.str)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("ParseField() error = %v, wantErr %v", err, testCase.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("ParseField() = %#v, want %#v", got, testCase.want)
+			}
+		})
+	}
+}
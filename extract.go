@@ -1,40 +1,219 @@
 package textra
 
 import (
+	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 // Extract accept a struct (or a pointer to a struct) and returns a map
 // of fields and their tags.
 // If src is not a struct or a pointer to a struct, nil is returned.
+// Blank (_) fields are struct metadata and aren't returned, see ExtractInfo.
 func Extract(src interface{}) Struct {
-	typ := reflect.TypeOf(src)
+	info, ok := ExtractInfo(src)
+	if !ok {
+		return nil
+	}
+
+	return info.Fields
+}
+
+// DuplicateTagError is returned by ExtractStrict if a tag key maps
+// two or more fields to the same value.
+type DuplicateTagError struct {
+	// Key is a tag's name, like "json".
+	Key string
+	// Value is the duplicated value, like "id".
+	Value string
+	// Fields holds names of conflicting fields.
+	Fields []string
+}
 
-	// If str is a struct pointer
+func (e *DuplicateTagError) Error() string {
+	return fmt.Sprintf("textra: duplicate %s:%q on fields %s",
+		e.Key, e.Value, strings.Join(e.Fields, ", "))
+}
+
+// ExtractStrict works like Extract, but also returns a *DuplicateTagError
+// if any tag key maps two fields to the same value.
+//
+// Values are resolved similar to encoding/json: ignored ("-") tags are
+// skipped, an empty value falls back to the field's name and fields of
+// embedded structs without a name are promoted, where a shallower field hides
+// the deeper ones.
+func ExtractStrict(src interface{}) (Struct, error) {
+	s := Extract(src)
+	if s == nil {
+		return nil, nil
+	}
+
+	typ := reflect.TypeOf(src)
 	if typ.Kind() == reflect.Ptr {
-		typ = typ.Elem() // dereference it
+		typ = typ.Elem()
 	}
 
-	if typ.Kind() != reflect.Struct {
-		return nil
+	keys := make([]string, 0)
+	for key := range collectTagKeys(typ, map[reflect.Type]bool{}) {
+		keys = append(keys, key)
 	}
 
-	amount := typ.NumField()
-	result := make(Struct, 0, amount)
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		dups := promotedDuplicates(typ, key)
+		if len(dups) == 0 {
+			continue
+		}
 
-	var f reflect.StructField
+		values := make([]string, 0, len(dups))
+		for value := range dups {
+			values = append(values, value)
+		}
 
-	for i := 0; i < amount; i++ {
-		f = typ.Field(i)
+		sort.Strings(values)
 
-		result = append(result, Field{
-			Name: f.Name,
-			Type: parseType(f.Type),
-			Tags: parseTags(f.Tag),
+		names := make([]string, 0, len(dups[values[0]]))
+		for _, f := range dups[values[0]] {
+			names = append(names, f.Name)
+		}
+
+		return s, &DuplicateTagError{Key: key, Value: values[0], Fields: names}
+	}
+
+	return s, nil
+}
+
+// collectTagKeys returns all tag keys used by typ and its embedded structs.
+func collectTagKeys(typ reflect.Type, visited map[reflect.Type]bool) map[string]struct{} {
+	keys := make(map[string]struct{})
+	if visited[typ] {
+		return keys
+	}
+
+	visited[typ] = true
+
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.Name == blankField {
+			continue
+		}
+
+		for _, tag := range parseTags(f.Tag) {
+			keys[tag.Tag] = struct{}{}
+		}
+
+		if embedded, ok := embeddedStruct(f); ok {
+			for key := range collectTagKeys(embedded, visited) {
+				keys[key] = struct{}{}
+			}
+		}
+	}
+
+	return keys
+}
+
+// promotedField is a field with its embedding depth.
+type promotedField struct {
+	Field
+	depth int
+}
+
+// promotedDuplicates returns all values of a key tag that are used by more
+// than one dominant field of typ.
+func promotedDuplicates(typ reflect.Type, key string) map[string][]Field {
+	byValue := make(map[string][]promotedField)
+	collectPromoted(typ, key, 0, map[reflect.Type]bool{}, byValue)
+
+	dups := make(map[string][]Field)
+
+	for value, fields := range byValue {
+		minDepth := fields[0].depth
+		for _, f := range fields {
+			if f.depth < minDepth {
+				minDepth = f.depth
+			}
+		}
+
+		dominant := make([]Field, 0, len(fields))
+		for _, f := range fields {
+			if f.depth == minDepth {
+				dominant = append(dominant, f.Field)
+			}
+		}
+
+		if len(dominant) > 1 {
+			dups[value] = dominant
+		}
+	}
+
+	return dups
+}
+
+func collectPromoted(
+	typ reflect.Type, key string, depth int,
+	visited map[reflect.Type]bool, byValue map[string][]promotedField,
+) {
+	if visited[typ] {
+		return
+	}
+
+	visited[typ] = true
+	defer delete(visited, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.Name == blankField {
+			continue
+		}
+
+		tags := parseTags(f.Tag)
+		tag, ok := tags.ByName(key)
+
+		if ok && tag.Ignored() {
+			continue
+		}
+
+		if embedded, isStruct := embeddedStruct(f); isStruct && tag.Value == "" {
+			collectPromoted(embedded, key, depth+1, visited, byValue)
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		value := tag.Value
+		if value == "" {
+			value = f.Name
+		}
+
+		byValue[value] = append(byValue[value], promotedField{
+			Field: Field{
+				Name:     f.Name,
+				Type:     parseType(f.Type),
+				Tags:     tags,
+				Embedded: f.Anonymous,
+			},
+			depth: depth,
 		})
 	}
+}
+
+// embeddedStruct returns the struct type of an embedded field, if f is
+// an embedded struct or a pointer to one.
+func embeddedStruct(f reflect.StructField) (reflect.Type, bool) {
+	if !f.Anonymous {
+		return nil, false
+	}
+
+	typ := f.Type
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
 
-	return result
+	return typ, typ.Kind() == reflect.Struct
 }
 
 // toUniqueMap accepts a slice of strings and returns a map of unique strings.
@@ -21,15 +21,15 @@ func TestExtract(t *testing.T) {
 			Name: "Tag1",
 			Type: "struct",
 			Tags: textra.Tags{
-				{"json", "tag1", nil},
+				{Tag: "json", Value: "tag1"},
 			},
 		},
 		textra.Field{
 			Name: "Tag2",
 			Type: "struct",
 			Tags: textra.Tags{
-				{"json", "tag2", nil},
-				{"sql", "tag2", []string{"pk"}},
+				{Tag: "json", Value: "tag2"},
+				{Tag: "sql", Value: "tag2", Optional: []string{"pk"}, Text: "tag2, pk"},
 			},
 		},
 	}
@@ -103,6 +103,8 @@ func TestExtractFieldType(t *testing.T) {
 		{"*struct {}", struct{ a *struct{} }{}, "a", "*struct {}"},
 		{"time.Time", struct{ a time.Time }{}, "a", "time.Time"},
 		{"*time.Time", struct{ a *time.Time }{}, "a", "*time.Time"},
+		{"[]time.Duration", struct{ a []time.Duration }{}, "a", "[]int64"},
+		{"map[string]*time.Duration", struct{ a map[string]*time.Duration }{}, "a", "map[string]*int64"},
 	}
 
 	for _, testCase := range testCases {
@@ -120,3 +122,77 @@ func TestExtractFieldType(t *testing.T) {
 		}
 	}
 }
+
+func TestExtractStrict(t *testing.T) {
+	type Base struct {
+		ID      int    `json:"id"`
+		Created string `json:"created"`
+	}
+
+	type Unique struct {
+		Base
+		ID   int    `json:"id"` // hides Base.ID
+		Name string `json:"name"`
+		Skip string `json:"-"`
+		Same string `json:"-"`
+	}
+
+	type Duplicated struct {
+		ID    int `db:"id"`
+		Other int `db:"id"`
+	}
+
+	type Left struct {
+		Name string `db:"name"`
+	}
+
+	type Right struct {
+		Title string `db:"name"`
+	}
+
+	type Promoted struct {
+		Left
+		*Right
+	}
+
+	type Named struct {
+		Base  `db:"base"`
+		Other struct{} `db:"base"`
+	}
+
+	testCases := []struct {
+		name    string
+		input   interface{}
+		wantErr *textra.DuplicateTagError
+	}{
+		{"unique", (*Unique)(nil), nil},
+		{"duplicated", (*Duplicated)(nil), &textra.DuplicateTagError{"db", "id", []string{"ID", "Other"}}},
+		{"promoted", Promoted{}, &textra.DuplicateTagError{"db", "name", []string{"Name", "Title"}}},
+		{"named embedded", Named{}, &textra.DuplicateTagError{"db", "base", []string{"Base", "Other"}}},
+		{"non-struct", 4, nil},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		_, err := textra.ExtractStrict(testCase.input)
+
+		if testCase.wantErr == nil {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", testCase.name, err)
+			}
+
+			continue
+		}
+
+		dupErr, ok := err.(*textra.DuplicateTagError)
+		if !ok {
+			t.Errorf("%s: got %v want %v", testCase.name, err, testCase.wantErr)
+			continue
+		}
+
+		if !reflect.DeepEqual(dupErr, testCase.wantErr) {
+			t.Errorf("%s: got %v want %v", testCase.name, dupErr, testCase.wantErr)
+		}
+	}
+}
@@ -10,6 +10,12 @@ type Field struct {
 	// Type is a type of a field, like "time.Time" or "*string"
 	Type string `json:"type"`
 	Tags Tags   `json:"tags,omitempty"`
+	// Embedded is true if the field is an embedded (anonymous) field.
+	Embedded bool `json:"embedded,omitempty"`
+	// Doc is the field's doc comment and Comment is its line comment,
+	// without comment markers. They are set only by ExtractDir.
+	Doc     string `json:"doc,omitempty"`
+	Comment string `json:"comment,omitempty"`
 }
 
 // FieldTag is like Field but it has only one tag.
@@ -21,6 +27,23 @@ type FieldTag struct {
 	Tag  Tag    `json:"tag,omitempty"`
 }
 
+// Description returns a value of the "desc" or "description" tag, if any,
+// else the doc comment, else the line comment. Tag values are returned as
+// written, see Tag.Raw.
+func (f Field) Description() string {
+	for _, name := range []string{"desc", "description"} {
+		if tag, ok := f.Tags.ByName(name); ok && tag.Raw() != "" {
+			return tag.Raw()
+		}
+	}
+
+	if f.Doc != "" {
+		return f.Doc
+	}
+
+	return f.Comment
+}
+
 func (f Field) String() string {
 	return fmt.Sprintf("%s(%s):%s", f.Name, f.Type, f.Tags.String())
 }
@@ -32,3 +32,34 @@ func TestFieldTag_String(t *testing.T) {
 		t.Errorf("FieldTag.String() = %q, expected %q", got, want)
 	}
 }
+
+func TestField_Description(t *testing.T) {
+	tests := []struct {
+		name  string
+		field textra.Field
+		want  string
+	}{
+		{"empty", textra.Field{}, ""},
+		{"comment", textra.Field{Comment: "comment"}, "comment"},
+		{"doc", textra.Field{Doc: "doc", Comment: "comment"}, "doc"},
+		{"description", textra.Field{
+			Tags: textra.Tags{{Tag: "description", Value: "A user or a bot"}},
+			Doc:  "doc",
+		}, "A user or a bot"},
+		{"comma", textra.Extract((*struct {
+			Port int `desc:"Port, in range 1-65535 "`
+		})(nil))[0], "Port, in range 1-65535 "},
+		{"desc", textra.Field{
+			Tags: textra.Tags{{Tag: "description", Value: "description"}, {Tag: "desc", Value: "desc"}},
+			Doc:  "doc",
+		}, "desc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.field.Description(); got != tt.want {
+				t.Errorf("Description() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
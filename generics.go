@@ -0,0 +1,161 @@
+//go:build go1.18
+// +build go1.18
+
+package textra
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// indexes caches an *Index of every struct type used by generic helpers.
+var indexes sync.Map // map[reflect.Type]*Index
+
+// typeIndex returns a cached Index of a struct type.
+func typeIndex(typ reflect.Type) (*Index, bool) {
+	if idx, ok := indexes.Load(typ); ok {
+		return idx.(*Index), true
+	}
+
+	idx := ExtractIndex(reflect.Zero(reflect.PtrTo(typ)).Interface())
+	if idx == nil {
+		return nil, false
+	}
+
+	cached, _ := indexes.LoadOrStore(typ, idx)
+
+	return cached.(*Index), true
+}
+
+// structValue returns a struct value v holds or points to.
+func structValue(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return reflect.Value{}, fmt.Errorf("textra: nil %s", rv.Type())
+		}
+
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("textra: %T is not a struct", v)
+	}
+
+	return rv, nil
+}
+
+// Of returns fields of T, which is a struct or a pointer to a struct, like
+// Extract((*T)(nil)). Extraction is cached per type, every call returns a
+// copy. Of returns nil if T is not a struct.
+func Of[T any]() Struct {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	idx, ok := typeIndex(typ)
+	if !ok {
+		return nil
+	}
+
+	return idx.Fields()
+}
+
+// FieldValue is a field with its value.
+type FieldValue struct {
+	Field
+	// Value is nil for unexported fields.
+	Value any
+}
+
+// Values returns fields of v with their values. v must be a struct or a
+// non-nil pointer to one, otherwise nil is returned.
+func Values[T any](v T) []FieldValue {
+	rv, err := structValue(v)
+	if err != nil {
+		return nil
+	}
+
+	idx, _ := typeIndex(rv.Type())
+	fields := idx.Fields()
+	values := make([]FieldValue, 0, len(fields))
+
+	for _, field := range fields {
+		fv := FieldValue{Field: field}
+		if f := rv.FieldByName(field.Name); f.CanInterface() {
+			fv.Value = f.Interface()
+		}
+
+		values = append(values, fv)
+	}
+
+	return values
+}
+
+// Get returns a value of the field of v with the tag value, like
+// Get[int](user, "json", "id"). v must be a struct or a pointer to one.
+// It returns an error if there's no such field, it's unexported or its value
+// is not a V.
+func Get[V any](v any, tagKey, tagValue string) (V, error) {
+	var zero V
+
+	f, err := fieldByTag(v, tagKey, tagValue)
+	if err != nil {
+		return zero, err
+	}
+
+	value, ok := f.Interface().(V)
+	if !ok {
+		return zero, fmt.Errorf("textra: field with %s:%q is %s, not %T", tagKey, tagValue, f.Type(), zero)
+	}
+
+	return value, nil
+}
+
+// Set sets a value of the field of v with the tag value, like
+// Set(&user, "json", "id", 42). v must be a non-nil pointer to a struct.
+// It returns an error if there's no such field, it's unexported or value
+// can't be assigned to it.
+func Set[V any](v any, tagKey, tagValue string, value V) error {
+	if reflect.ValueOf(v).Kind() != reflect.Ptr {
+		return fmt.Errorf("textra: can't set a field of %T, a pointer is required", v)
+	}
+
+	f, err := fieldByTag(v, tagKey, tagValue)
+	if err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(&value).Elem()
+	if !rv.Type().AssignableTo(f.Type()) {
+		return fmt.Errorf("textra: can't assign %s to field with %s:%q of type %s", rv.Type(), tagKey, tagValue, f.Type())
+	}
+
+	f.Set(rv)
+
+	return nil
+}
+
+// fieldByTag returns a field of v by its tag value.
+func fieldByTag(v any, tagKey, tagValue string) (reflect.Value, error) {
+	rv, err := structValue(v)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	idx, _ := typeIndex(rv.Type())
+
+	field, ok := idx.ByTag(tagKey, tagValue)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("textra: %s has no field with %s:%q", rv.Type(), tagKey, tagValue)
+	}
+
+	f := rv.FieldByName(field.Name)
+	if !f.CanInterface() {
+		return reflect.Value{}, fmt.Errorf("textra: field %s of %s is unexported", field.Name, rv.Type())
+	}
+
+	return f, nil
+}
@@ -0,0 +1,146 @@
+//go:build go1.18
+// +build go1.18
+
+package textra_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ravsii/textra"
+)
+
+type genericUser struct {
+	ID     int      `json:"id"`
+	Name   *string  `json:"name"`
+	Tags   []string `json:"tags"`
+	secret string   `db:"secret"`
+}
+
+func TestOf(t *testing.T) {
+	t.Parallel()
+
+	want := textra.Extract((*genericUser)(nil))
+
+	if got := textra.Of[genericUser](); !reflect.DeepEqual(got, want) {
+		t.Errorf("Of() = %v, want %v", got, want)
+	}
+
+	if got := textra.Of[*genericUser](); !reflect.DeepEqual(got, want) {
+		t.Errorf("Of() of a pointer = %v, want %v", got, want)
+	}
+
+	if got := textra.Of[int](); got != nil {
+		t.Errorf("Of[int]() = %v, want nil", got)
+	}
+}
+
+func TestOf_Copy(t *testing.T) {
+	t.Parallel()
+
+	want := textra.Extract((*genericUser)(nil))
+
+	fields := textra.Of[genericUser]()
+	fields[0].Tags[0].Value = "changed"
+	fields[1].Tags = nil
+	fields[2].Name = "Changed"
+
+	values := textra.Values(genericUser{})
+	values[0].Tags[0].Optional = append(values[0].Tags[0].Optional, "omitempty")
+
+	if got := textra.Of[genericUser](); !reflect.DeepEqual(got, want) {
+		t.Errorf("Of() after modification = %v, want %v", got, want)
+	}
+
+	if _, err := textra.Get[int](genericUser{}, "json", "id"); err != nil {
+		t.Errorf("Get() after modification: %v", err)
+	}
+}
+
+func TestValues(t *testing.T) {
+	t.Parallel()
+
+	name := "gopher"
+	user := genericUser{ID: 1, Name: &name, secret: "x"}
+
+	values := textra.Values(&user)
+	if len(values) != 4 {
+		t.Fatalf("Values() = %v", values)
+	}
+
+	if values[0].Name != "ID" || values[0].Value != 1 {
+		t.Errorf("Values()[0] = %v", values[0])
+	}
+
+	if values[1].Value != &name {
+		t.Errorf("Values()[1] = %v", values[1])
+	}
+
+	if values[3].Value != nil {
+		t.Errorf("Values() of an unexported field = %v, want nil", values[3].Value)
+	}
+
+	if got := textra.Values(42); got != nil {
+		t.Errorf("Values(42) = %v, want nil", got)
+	}
+}
+
+func TestGetSet(t *testing.T) {
+	t.Parallel()
+
+	user := genericUser{ID: 1}
+
+	if err := textra.Set(&user, "json", "id", 42); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := textra.Set(&user, "json", "tags", []string{"a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := textra.Get[int](user, "json", "id")
+	if err != nil || id != 42 {
+		t.Errorf("Get() = %v, %v, want 42", id, err)
+	}
+
+	tags, err := textra.Get[[]string](&user, "json", "tags")
+	if err != nil || !reflect.DeepEqual(tags, []string{"a"}) {
+		t.Errorf("Get() = %v, %v, want [a]", tags, err)
+	}
+
+	name, err := textra.Get[*string](&user, "json", "name")
+	if err != nil || name != nil {
+		t.Errorf("Get() = %v, %v, want nil", name, err)
+	}
+}
+
+func TestGetSet_Errors(t *testing.T) {
+	t.Parallel()
+
+	user := genericUser{}
+
+	testCases := []struct {
+		name string
+		err  error
+	}{
+		{"get missing field", getErr[int](user, "json", "missing")},
+		{"get wrong type", getErr[string](user, "json", "id")},
+		{"get unexported", getErr[string](user, "db", "secret")},
+		{"get not a struct", getErr[int](42, "json", "id")},
+		{"get nil pointer", getErr[int]((*genericUser)(nil), "json", "id")},
+		{"set not a pointer", textra.Set(user, "json", "id", 1)},
+		{"set wrong type", textra.Set(&user, "json", "id", "1")},
+		{"set missing field", textra.Set(&user, "db", "id", 1)},
+	}
+
+	for _, testCase := range testCases {
+		if testCase.err == nil {
+			t.Errorf("%s: error = nil", testCase.name)
+		}
+	}
+}
+
+func getErr[V any](v any, tagKey, tagValue string) error {
+	_, err := textra.Get[V](v, tagKey, tagValue)
+	return err
+}
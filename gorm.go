@@ -0,0 +1,335 @@
+package textra
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ravsii/textra/internal/naming"
+)
+
+// defaultGormIndexPriority is the priority gorm uses for composite index
+// fields without an explicit "priority" setting.
+const defaultGormIndexPriority = 10
+
+// GormTag is a parsed gorm tag, like
+//
+//	`gorm:"column:user_name;type:varchar(64);uniqueIndex:idx_name,sort:desc"`.
+//
+// It's returned by Tag.Parse for gorm tags.
+type GormTag struct {
+	// Column holds a column name, if it's set explicitly.
+	Column string `json:"column,omitempty"`
+	// Type holds a column type, like "varchar(64)".
+	Type          string `json:"type,omitempty"`
+	Size          int    `json:"size,omitempty"`
+	PrimaryKey    bool   `json:"primaryKey,omitempty"`
+	Unique        bool   `json:"unique,omitempty"`
+	NotNull       bool   `json:"notNull,omitempty"`
+	AutoIncrement bool   `json:"autoIncrement,omitempty"`
+	// Default holds a default value as it's written, like "'x'".
+	// Use HasDefault to tell an empty default from a missing one.
+	Default    string `json:"default,omitempty"`
+	HasDefault bool   `json:"hasDefault,omitempty"`
+	// Ignored is true for "-" and "-:all", which make gorm skip the field.
+	Ignored  bool             `json:"ignored,omitempty"`
+	Indexes  []GormFieldIndex `json:"indexes,omitempty"`
+	Relation GormRelation     `json:"relation"`
+	// Settings holds all the settings, with upper-cased keys (like gorm
+	// does), including the ones which are not listed above.
+	Settings map[string]string `json:"settings,omitempty"`
+}
+
+// GormFieldIndex is an "index" or "uniqueIndex" setting of a single field.
+type GormFieldIndex struct {
+	// Name is an index name. Fields with the same name form a composite
+	// index. It's empty if gorm should generate the name.
+	Name       string `json:"name,omitempty"`
+	Unique     bool   `json:"unique,omitempty"`
+	Class      string `json:"class,omitempty"`
+	Type       string `json:"type,omitempty"`
+	Where      string `json:"where,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+	Option     string `json:"option,omitempty"`
+	Sort       string `json:"sort,omitempty"`
+	Collate    string `json:"collate,omitempty"`
+	Expression string `json:"expression,omitempty"`
+	Length     int    `json:"length,omitempty"`
+	Priority   int    `json:"priority"`
+}
+
+// GormRelation holds relationship settings of a field.
+type GormRelation struct {
+	ForeignKey       string `json:"foreignKey,omitempty"`
+	References       string `json:"references,omitempty"`
+	Polymorphic      string `json:"polymorphic,omitempty"`
+	PolymorphicValue string `json:"polymorphicValue,omitempty"`
+	Many2Many        string `json:"many2many,omitempty"`
+	JoinForeignKey   string `json:"joinForeignKey,omitempty"`
+	JoinReferences   string `json:"joinReferences,omitempty"`
+	// Constraint is written as is, like "OnUpdate:CASCADE,OnDelete:SET NULL".
+	Constraint string `json:"constraint,omitempty"`
+}
+
+// GormIndex is an index built from one or more fields of a Struct.
+type GormIndex struct {
+	Name    string `json:"name"`
+	Unique  bool   `json:"unique,omitempty"`
+	Class   string `json:"class,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Where   string `json:"where,omitempty"`
+	Comment string `json:"comment,omitempty"`
+	Option  string `json:"option,omitempty"`
+	// Fields are sorted by priority, then by their order in the Struct.
+	Fields []GormIndexField `json:"fields"`
+}
+
+// GormIndexField is a single field of a GormIndex.
+type GormIndexField struct {
+	// Field is a struct field's name.
+	Field string `json:"field"`
+	// Column is a column name, either from the tag or a snake_cased
+	// field's name, like gorm's default naming strategy does.
+	Column     string `json:"column"`
+	Sort       string `json:"sort,omitempty"`
+	Collate    string `json:"collate,omitempty"`
+	Expression string `json:"expression,omitempty"`
+	Length     int    `json:"length,omitempty"`
+	Priority   int    `json:"priority"`
+}
+
+var gormTagParser = TagParserFunc(func(tag Tag) (interface{}, error) {
+	return ParseGormTag(tag)
+})
+
+// ParseGormTag parses a gorm tag. Settings are separated by semicolons
+// (escaped with a backslash), keys are case-insensitive and everything after
+// the first colon is a value.
+func ParseGormTag(tag Tag) (GormTag, error) {
+	raw := tag.Raw()
+	parsed := GormTag{Settings: make(map[string]string)}
+
+	for _, setting := range splitEscaped(raw, ';') {
+		if strings.TrimSpace(setting) == "" {
+			continue
+		}
+
+		key, value := splitGormSetting(setting)
+		parsed.Settings[key] = value
+
+		var err error
+
+		switch key {
+		case "-":
+			parsed.Ignored = value == "" || strings.EqualFold(value, "all")
+		case "COLUMN":
+			parsed.Column = value
+		case "TYPE":
+			parsed.Type = value
+		case "SIZE":
+			parsed.Size, err = strconv.Atoi(value)
+		case "PRIMARYKEY", "PRIMARY_KEY":
+			parsed.PrimaryKey = true
+		case "UNIQUE":
+			parsed.Unique = true
+		case "NOT NULL", "NOTNULL":
+			parsed.NotNull = true
+		case "AUTOINCREMENT":
+			parsed.AutoIncrement = true
+		case "DEFAULT":
+			parsed.Default, parsed.HasDefault = value, true
+		case "INDEX", "UNIQUEINDEX":
+			var index GormFieldIndex
+
+			index, err = parseGormIndex(value, key == "UNIQUEINDEX")
+			parsed.Indexes = append(parsed.Indexes, index)
+		case "FOREIGNKEY":
+			parsed.Relation.ForeignKey = value
+		case "REFERENCES":
+			parsed.Relation.References = value
+		case "POLYMORPHIC":
+			parsed.Relation.Polymorphic = value
+		case "POLYMORPHICVALUE":
+			parsed.Relation.PolymorphicValue = value
+		case "MANY2MANY":
+			parsed.Relation.Many2Many = value
+		case "JOINFOREIGNKEY":
+			parsed.Relation.JoinForeignKey = value
+		case "JOINREFERENCES":
+			parsed.Relation.JoinReferences = value
+		case "CONSTRAINT":
+			parsed.Relation.Constraint = value
+		}
+
+		if err != nil {
+			return GormTag{}, fmt.Errorf("textra: gorm %s: %v", strings.ToLower(key), err)
+		}
+	}
+
+	return parsed, nil
+}
+
+// parseGormIndex parses a value of an index setting, like
+//
+//	idx_name,unique,sort:desc,priority:2.
+func parseGormIndex(value string, unique bool) (GormFieldIndex, error) {
+	parts := strings.Split(value, ",")
+	index := GormFieldIndex{
+		Name:     strings.TrimSpace(parts[0]),
+		Unique:   unique,
+		Priority: defaultGormIndexPriority,
+	}
+
+	var err error
+
+	for _, part := range parts[1:] {
+		key, v := splitGormSetting(part)
+
+		switch key {
+		case "UNIQUE":
+			index.Unique = true
+		case "CLASS":
+			index.Class = v
+		case "TYPE":
+			index.Type = v
+		case "WHERE":
+			index.Where = v
+		case "COMMENT":
+			index.Comment = v
+		case "OPTION":
+			index.Option = v
+		case "SORT":
+			index.Sort = v
+		case "COLLATE":
+			index.Collate = v
+		case "EXPRESSION":
+			index.Expression = v
+		case "LENGTH":
+			index.Length, err = strconv.Atoi(v)
+		case "PRIORITY":
+			index.Priority, err = strconv.Atoi(v)
+		}
+
+		if err != nil {
+			return GormFieldIndex{}, fmt.Errorf("index %s: %v", strings.ToLower(key), err)
+		}
+	}
+
+	if index.Class == "UNIQUE" {
+		index.Unique = true
+	}
+
+	return index, nil
+}
+
+// splitGormSetting splits a "key:value" setting. The key is upper-cased and
+// trimmed, the value holds everything after the first colon.
+func splitGormSetting(setting string) (string, string) {
+	split := strings.SplitN(setting, ":", 2)
+	key := strings.ToUpper(strings.TrimSpace(split[0]))
+
+	if len(split) == 1 {
+		return key, ""
+	}
+
+	return key, strings.TrimSpace(split[1])
+}
+
+// splitEscaped splits s by sep, unless sep is escaped with a backslash.
+func splitEscaped(s string, sep byte) []string {
+	parts := make([]string, 0)
+	current := make([]byte, 0, len(s))
+
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == sep:
+			current = append(current, sep)
+			i++
+		case s[i] == sep:
+			parts = append(parts, string(current))
+			current = current[:0]
+		default:
+			current = append(current, s[i])
+		}
+	}
+
+	return append(parts, string(current))
+}
+
+// GormIndexes returns all indexes defined by gorm tags of s. Fields with the
+// same index name are grouped into a composite index. Unnamed indexes are
+// named "idx_<column>" (gorm also prepends a table name, which is not known
+// here).
+// Indexes are returned in order of their first appearance.
+func (s Struct) GormIndexes() ([]GormIndex, error) {
+	indexes := make([]GormIndex, 0)
+	byName := make(map[string]int)
+
+	for _, field := range s {
+		tag, ok := field.Tags.ByName("gorm")
+		if !ok {
+			continue
+		}
+
+		gorm, err := ParseGormTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", field.Name, err)
+		}
+
+		column := gorm.Column
+		if column == "" {
+			column = naming.Snake(field.Name)
+		}
+
+		for _, fi := range gorm.Indexes {
+			name := fi.Name
+			if name == "" {
+				name = "idx_" + column
+			}
+
+			i, ok := byName[name]
+			if !ok {
+				i = len(indexes)
+				byName[name] = i
+				indexes = append(indexes, GormIndex{Name: name})
+			}
+
+			index := &indexes[i]
+			index.Unique = index.Unique || fi.Unique
+			index.Class = firstNonEmpty(index.Class, fi.Class)
+			index.Type = firstNonEmpty(index.Type, fi.Type)
+			index.Where = firstNonEmpty(index.Where, fi.Where)
+			index.Comment = firstNonEmpty(index.Comment, fi.Comment)
+			index.Option = firstNonEmpty(index.Option, fi.Option)
+			index.Fields = append(index.Fields, GormIndexField{
+				Field:      field.Name,
+				Column:     column,
+				Sort:       fi.Sort,
+				Collate:    fi.Collate,
+				Expression: fi.Expression,
+				Length:     fi.Length,
+				Priority:   fi.Priority,
+			})
+		}
+	}
+
+	for i := range indexes {
+		fields := indexes[i].Fields
+		sort.SliceStable(fields, func(a, b int) bool {
+			return fields[a].Priority < fields[b].Priority
+		})
+	}
+
+	return indexes, nil
+}
+
+func firstNonEmpty(strs ...string) string {
+	for _, s := range strs {
+		if s != "" {
+			return s
+		}
+	}
+
+	return ""
+}
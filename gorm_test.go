@@ -0,0 +1,113 @@
+package textra_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ravsii/textra"
+)
+
+func TestParseGormTag(t *testing.T) {
+	type Tester struct {
+		Name    string `gorm:"column:user_name;type:varchar(64);uniqueIndex:idx_name,sort:desc;default:'x'"`
+		ID      uint   `gorm:"primaryKey;autoIncrement;not null"`
+		Ignored string `gorm:"-"`
+		Company string `gorm:"foreignKey:CompanyRefer;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+		Bad     string `gorm:"size:big"`
+		Escaped string `gorm:"check:a\\;b;size:10"`
+	}
+
+	data := textra.Extract((*Tester)(nil))
+
+	testCases := []struct {
+		fieldName string
+		want      textra.GormTag
+		wantErr   bool
+	}{
+		{"Name", textra.GormTag{
+			Column:     "user_name",
+			Type:       "varchar(64)",
+			Default:    "'x'",
+			HasDefault: true,
+			Indexes: []textra.GormFieldIndex{
+				{Name: "idx_name", Unique: true, Sort: "desc", Priority: 10},
+			},
+			Settings: map[string]string{
+				"COLUMN":      "user_name",
+				"TYPE":        "varchar(64)",
+				"UNIQUEINDEX": "idx_name,sort:desc",
+				"DEFAULT":     "'x'",
+			},
+		}, false},
+		{"ID", textra.GormTag{
+			PrimaryKey:    true,
+			AutoIncrement: true,
+			NotNull:       true,
+			Settings:      map[string]string{"PRIMARYKEY": "", "AUTOINCREMENT": "", "NOT NULL": ""},
+		}, false},
+		{"Ignored", textra.GormTag{Ignored: true, Settings: map[string]string{"-": ""}}, false},
+		{"Company", textra.GormTag{
+			Relation: textra.GormRelation{
+				ForeignKey: "CompanyRefer",
+				Constraint: "OnUpdate:CASCADE,OnDelete:SET NULL",
+			},
+			Settings: map[string]string{
+				"FOREIGNKEY": "CompanyRefer",
+				"CONSTRAINT": "OnUpdate:CASCADE,OnDelete:SET NULL",
+			},
+		}, false},
+		{"Bad", textra.GormTag{}, true},
+		{"Escaped", textra.GormTag{Size: 10, Settings: map[string]string{"CHECK": "a;b", "SIZE": "10"}}, false},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		field, _ := data.Field(testCase.fieldName)
+
+		got, found, err := field.Tags.ParseByName("gorm")
+		if !found || (err != nil) != testCase.wantErr {
+			t.Errorf("%s: found %t, err %v", testCase.fieldName, found, err)
+			continue
+		}
+
+		if err == nil && !reflect.DeepEqual(got, testCase.want) {
+			t.Errorf("%s: got %+v want %+v", testCase.fieldName, got, testCase.want)
+		}
+	}
+}
+
+func TestGormIndexes(t *testing.T) {
+	type Tester struct {
+		Name      string `gorm:"index:idx_member,priority:2"`
+		Number    string `gorm:"index:idx_member,priority:1;uniqueIndex"`
+		UserID    int    `gorm:"index"`
+		Email     string `gorm:"column:mail;index:,class:FULLTEXT,where:mail != ''"`
+		NoIndexes string `gorm:"column:none"`
+	}
+
+	got, err := textra.Extract((*Tester)(nil)).GormIndexes()
+	if err != nil {
+		t.Fatalf("GormIndexes() error = %v", err)
+	}
+
+	want := []textra.GormIndex{
+		{Name: "idx_member", Fields: []textra.GormIndexField{
+			{Field: "Number", Column: "number", Priority: 1},
+			{Field: "Name", Column: "name", Priority: 2},
+		}},
+		{Name: "idx_number", Unique: true, Fields: []textra.GormIndexField{
+			{Field: "Number", Column: "number", Priority: 10},
+		}},
+		{Name: "idx_user_id", Fields: []textra.GormIndexField{
+			{Field: "UserID", Column: "user_id", Priority: 10},
+		}},
+		{Name: "idx_mail", Class: "FULLTEXT", Where: "mail != ''", Fields: []textra.GormIndexField{
+			{Field: "Email", Column: "mail", Priority: 10},
+		}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GormIndexes() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,205 @@
+// Package graphqlgen generates GraphQL SDL object types from textra Structs.
+//
+// Field names come from graphql tags, then json tags, then lowerCamelCased
+// field names. Pointers are nullable, everything else is non-null.
+// Unexported and embedded fields are skipped.
+// A graphql tag may override a field's type or skip it:
+//
+//	`graphql:"id,type=ID"`
+//	`graphql:"-"`
+package graphqlgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ravsii/textra"
+	"github.com/ravsii/textra/internal/naming"
+	"github.com/ravsii/textra/internal/typestr"
+)
+
+// scalars maps Go types to GraphQL scalars. Time is a custom scalar, which
+// is declared if it's used.
+var scalars = map[string]string{
+	"bool":      "Boolean",
+	"int":       "Int",
+	"int8":      "Int",
+	"int16":     "Int",
+	"int32":     "Int",
+	"int64":     "Int",
+	"uint":      "Int",
+	"uint8":     "Int",
+	"uint16":    "Int",
+	"uint32":    "Int",
+	"uint64":    "Int",
+	"float32":   "Float",
+	"float64":   "Float",
+	"string":    "String",
+	"time.Time": "Time",
+}
+
+// builtinScalars are scalars which don't need a declaration.
+var builtinScalars = map[string]bool{
+	"Int": true, "Float": true, "String": true, "Boolean": true, "ID": true,
+}
+
+// Object is a Struct with a name, which becomes an object type.
+type Object struct {
+	Name   string
+	Fields textra.Struct
+}
+
+// Generate returns SDL with the given object types. Custom scalars,
+// like Time, are declared first. Fields of named struct types reference
+// objects by their names, without packages.
+func Generate(objects ...Object) (string, error) {
+	custom := make(map[string]bool)
+	bodies := make([]string, 0, len(objects))
+
+	for _, obj := range objects {
+		body, err := object(obj, custom)
+		if err != nil {
+			return "", err
+		}
+
+		bodies = append(bodies, body)
+	}
+
+	var sb strings.Builder
+
+	if len(custom) > 0 {
+		names := make([]string, 0, len(custom))
+		for name := range custom {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			sb.WriteString("scalar " + name + "\n")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(strings.Join(bodies, "\n"))
+
+	return sb.String(), nil
+}
+
+func object(obj Object, custom map[string]bool) (string, error) {
+	lines := make([]string, 0, len(obj.Fields))
+
+	for _, field := range obj.Fields {
+		if !isExported(field.Name) || field.Embedded {
+			continue
+		}
+
+		name, typ, skip := fieldTag(field)
+		if skip {
+			continue
+		}
+
+		if typ == "" {
+			var err error
+
+			typ, err = gqlType(typestr.Parse(field.Type))
+			if err != nil {
+				return "", fmt.Errorf("graphqlgen: %s.%s: %v", obj.Name, field.Name, err)
+			}
+		}
+
+		if base := strings.Trim(typ, "[]!"); !builtinScalars[base] && isCustomScalar(base) {
+			custom[base] = true
+		}
+
+		lines = append(lines, "  "+name+": "+typ)
+	}
+
+	return "type " + obj.Name + " {\n" + strings.Join(lines, "\n") + "\n}\n", nil
+}
+
+// fieldTag returns a field's name and an overridden type, if any,
+// or true if the field should be skipped.
+func fieldTag(field textra.Field) (string, string, bool) {
+	name := naming.LowerCamel(field.Name)
+	typ := ""
+
+	if tag, ok := field.Tags.ByName("json"); ok {
+		if tag.Ignored() && len(tag.Optional) == 0 {
+			return "", "", true
+		}
+
+		if tag.Value != "" {
+			name = tag.Value
+		}
+	}
+
+	if tag, ok := field.Tags.ByName("graphql"); ok {
+		if tag.Ignored() {
+			return "", "", true
+		}
+
+		if tag.Value != "" {
+			name = tag.Value
+		}
+
+		if v, ok := tag.Option("type"); ok {
+			typ = v
+		}
+	}
+
+	return name, typ, false
+}
+
+// gqlType returns a GraphQL type of t, which is non-null unless it's
+// a pointer.
+func gqlType(t *typestr.Type) (string, error) {
+	base, nullable := t.Deref()
+
+	var typ string
+
+	switch {
+	case base.Kind == typestr.Slice:
+		elem, err := gqlType(base.Elem)
+		if err != nil {
+			return "", err
+		}
+
+		typ = "[" + elem + "]"
+	case base.Kind == typestr.Map:
+		return "", fmt.Errorf("maps are not supported, use the type option")
+	case scalars[base.Name] != "":
+		typ = scalars[base.Name]
+	case base.Qualified():
+		typ = base.BaseName()
+	default:
+		return "", fmt.Errorf("unsupported type %s, use the type option", base.Name)
+	}
+
+	if !nullable {
+		typ += "!"
+	}
+
+	return typ, nil
+}
+
+// isCustomScalar reports whether name is one of scalars which need
+// a declaration.
+func isCustomScalar(name string) bool {
+	for _, scalar := range scalars {
+		if scalar == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isExported(name string) bool {
+	r, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(r)
+}
@@ -0,0 +1,81 @@
+package graphqlgen_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ravsii/textra"
+	"github.com/ravsii/textra/graphqlgen"
+)
+
+type Address struct {
+	City string `json:"city"`
+}
+
+// Status is a named non-struct type, which is a scalar in GraphQL.
+type Status int32
+
+type User struct {
+	ID        string     `graphql:"id,type=ID!"`
+	FullName  string     `json:"name"`
+	Nick      *string    `json:"nick,omitempty"`
+	Tags      []string   `json:"tags"`
+	Friends   []*User    `json:"friends"`
+	Home      *Address   `json:"home"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `graphql:"deletedAt"`
+	UserID    int
+	Statuses  []Status          `json:"statuses"`
+	Timeouts  []time.Duration   `json:"timeouts"`
+	Secret    string            `json:"-"`
+	Hidden    string            `graphql:"-"`
+	Labels    map[string]string `graphql:"labels,type=JSON"`
+	private   int
+}
+
+func TestGenerate(t *testing.T) {
+	got, err := graphqlgen.Generate(
+		graphqlgen.Object{Name: "User", Fields: textra.Extract((*User)(nil))},
+		graphqlgen.Object{Name: "Address", Fields: textra.Extract((*Address)(nil))},
+	)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := `scalar Time
+
+type User {
+  id: ID!
+  name: String!
+  nick: String
+  tags: [String!]!
+  friends: [User]!
+  home: Address
+  created_at: Time!
+  deletedAt: Time
+  userID: Int!
+  statuses: [Int!]!
+  timeouts: [Int!]!
+  labels: JSON
+}
+
+type Address {
+  city: String!
+}
+`
+
+	if got != want {
+		t.Errorf("Generate() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	type WithMap struct {
+		Labels map[string]string
+	}
+
+	obj := graphqlgen.Object{Name: "WithMap", Fields: textra.Extract((*WithMap)(nil))}
+	if _, err := graphqlgen.Generate(obj); err == nil {
+		t.Errorf("Generate() should fail for maps without a type")
+	}
+}
@@ -0,0 +1,298 @@
+package textra
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// Index is a read-only view of a Struct with constant time lookups. It's
+// safe for concurrent use.
+type Index struct {
+	fields Struct
+	byName map[string]int
+	// byTag maps a tag name to tag values to field indexes.
+	byTag map[string]map[string]int
+	// jsonFields are fields encoding/json sees, including promoted ones.
+	// json and jsonFold map encoding/json names to their indexes.
+	jsonFields Struct
+	json       map[string]int
+	jsonFold   map[string]int
+}
+
+// Index builds an Index of s. If several fields share a name or a tag
+// value, the first one is used.
+//
+// Fields of embedded structs aren't a part of s, so JSON can't find keys
+// promoted from them, use ExtractIndex for that.
+func (s Struct) Index() *Index {
+	candidates := make([]jsonCandidate, 0, len(s))
+
+	for _, field := range s {
+		if !isExported(field.Name) && !field.Embedded {
+			continue
+		}
+
+		tag, tagged := field.Tags.ByName("json")
+		if tagged && tag.Ignored() && len(tag.Optional) == 0 {
+			continue
+		}
+
+		name := tag.Value
+		if name == "" {
+			if field.Embedded {
+				continue
+			}
+
+			name, tagged = field.Name, false
+		}
+
+		candidates = append(candidates, jsonCandidate{field: field, name: name, tagged: tagged})
+	}
+
+	return newIndex(s, candidates)
+}
+
+// ExtractIndex is like Extract(src).Index(), but JSON also finds fields
+// promoted from embedded structs, following encoding/json rules: a
+// shallower field hides deeper ones with the same name. It returns nil if
+// src is not a struct or a pointer to a struct.
+func ExtractIndex(src interface{}) *Index {
+	s := Extract(src)
+	if s == nil {
+		return nil
+	}
+
+	typ := reflect.TypeOf(src)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return newIndex(s, collectJSON(typ, 0, map[reflect.Type]bool{}))
+}
+
+func newIndex(s Struct, candidates []jsonCandidate) *Index {
+	idx := &Index{
+		fields:   s,
+		byName:   make(map[string]int, len(s)),
+		byTag:    make(map[string]map[string]int),
+		json:     make(map[string]int),
+		jsonFold: make(map[string]int),
+	}
+
+	for i, field := range s {
+		if _, ok := idx.byName[field.Name]; !ok {
+			idx.byName[field.Name] = i
+		}
+
+		for _, tag := range field.Tags {
+			values, ok := idx.byTag[tag.Tag]
+			if !ok {
+				values = make(map[string]int)
+				idx.byTag[tag.Tag] = values
+			}
+
+			if _, ok := values[tag.Value]; !ok {
+				values[tag.Value] = i
+			}
+		}
+	}
+
+	idx.indexJSON(candidates)
+
+	return idx
+}
+
+// jsonCandidate is a field encoding/json may decode a name into.
+type jsonCandidate struct {
+	field  Field
+	name   string
+	tagged bool
+	depth  int
+}
+
+// collectJSON returns candidates of typ and structs embedded into it, like
+// encoding/json sees them: ignored and unexported fields are skipped and
+// embedded structs without a json name are replaced by their fields.
+func collectJSON(typ reflect.Type, depth int, visited map[reflect.Type]bool) []jsonCandidate {
+	if visited[typ] {
+		return nil
+	}
+
+	visited[typ] = true
+	defer delete(visited, typ)
+
+	candidates := make([]jsonCandidate, 0, typ.NumField())
+
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.Name == blankField {
+			continue
+		}
+
+		tags := parseTags(f.Tag)
+
+		tag, tagged := tags.ByName("json")
+		if tagged && tag.Ignored() && len(tag.Optional) == 0 {
+			continue
+		}
+
+		if f.Anonymous {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			if !isExported(f.Name) && ft.Kind() != reflect.Struct {
+				continue
+			}
+
+			if tag.Value == "" && ft.Kind() == reflect.Struct {
+				candidates = append(candidates, collectJSON(ft, depth+1, visited)...)
+				continue
+			}
+		} else if !isExported(f.Name) {
+			continue
+		}
+
+		name := tag.Value
+		if name == "" {
+			name, tagged = f.Name, false
+		}
+
+		candidates = append(candidates, jsonCandidate{
+			field: Field{
+				Name:     f.Name,
+				Type:     parseType(f.Type),
+				Tags:     tags,
+				Embedded: f.Anonymous,
+			},
+			name:   name,
+			tagged: tagged,
+			depth:  depth,
+		})
+	}
+
+	return candidates
+}
+
+// indexJSON indexes candidates by their names. If several fields have the
+// same name, the shallowest one wins, then a tagged one, if there's only
+// one, otherwise the name is ambiguous and isn't indexed.
+func (idx *Index) indexJSON(candidates []jsonCandidate) {
+	type dominant struct {
+		jsonCandidate
+		count int
+	}
+
+	names := make([]string, 0, len(candidates))
+	byName := make(map[string]*dominant)
+
+	for _, c := range candidates {
+		d, ok := byName[c.name]
+
+		switch {
+		case !ok:
+			names = append(names, c.name)
+			byName[c.name] = &dominant{jsonCandidate: c, count: 1}
+		case c.depth < d.depth, c.depth == d.depth && c.tagged && !d.tagged:
+			*d = dominant{jsonCandidate: c, count: 1}
+		case c.depth == d.depth && c.tagged == d.tagged:
+			d.count++
+		}
+	}
+
+	for _, name := range names {
+		d := byName[name]
+		if d.count > 1 {
+			continue
+		}
+
+		idx.json[name] = len(idx.jsonFields)
+
+		if _, ok := idx.jsonFold[foldName(name)]; !ok {
+			idx.jsonFold[foldName(name)] = len(idx.jsonFields)
+		}
+
+		idx.jsonFields = append(idx.jsonFields, d.field)
+	}
+}
+
+// Fields returns a copy of indexed fields.
+func (idx *Index) Fields() Struct {
+	return cloneStruct(idx.fields)
+}
+
+// Field returns a field by its Go name.
+func (idx *Index) Field(name string) (Field, bool) {
+	return get(idx.fields, idx.byName, name)
+}
+
+// ByTag returns the first field with the given tag value, like a field
+// with `json:"user_id"` for ByTag("json", "user_id").
+func (idx *Index) ByTag(tag, value string) (Field, bool) {
+	return get(idx.fields, idx.byTag[tag], value)
+}
+
+// JSON returns a field encoding/json decodes the key into. Like
+// encoding/json, it prefers an exact match of the name, but also accepts a
+// case-insensitive one. For an Index built by ExtractIndex, the field may
+// belong to an embedded struct.
+func (idx *Index) JSON(key string) (Field, bool) {
+	if f, ok := get(idx.jsonFields, idx.json, key); ok {
+		return f, true
+	}
+
+	return get(idx.jsonFields, idx.jsonFold, foldName(key))
+}
+
+// get returns a copy of a field, so changes of it don't reach the index.
+func get(fields Struct, m map[string]int, key string) (Field, bool) {
+	i, ok := m[key]
+	if !ok {
+		return Field{}, false
+	}
+
+	return cloneField(fields[i]), true
+}
+
+// cloneStruct returns a deep copy of s.
+func cloneStruct(s Struct) Struct {
+	clone := make(Struct, len(s))
+	for i, field := range s {
+		clone[i] = cloneField(field)
+	}
+
+	return clone
+}
+
+func cloneField(f Field) Field {
+	tags := make(Tags, len(f.Tags))
+	for i, tag := range f.Tags {
+		if tag.Optional != nil {
+			tag.Optional = append([]string(nil), tag.Optional...)
+		}
+
+		tags[i] = tag
+	}
+
+	f.Tags = tags
+
+	return f
+}
+
+// foldName returns a key equal for all strings equal under strings.EqualFold.
+func foldName(s string) string {
+	return strings.Map(func(r rune) rune {
+		// SimpleFold iterates over an orbit of equivalent runes, use the
+		// smallest one.
+		lowest := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < lowest {
+				lowest = f
+			}
+		}
+
+		return lowest
+	}, s)
+}
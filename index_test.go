@@ -0,0 +1,174 @@
+package textra_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ravsii/textra"
+)
+
+type indexEmbedded struct{}
+
+type indexStruct struct {
+	indexEmbedded
+	UserID   int    `json:"user_id" db:"uid"`
+	Name     string `db:"name"`
+	Title    string `json:"title"`
+	TITLE    string
+	Skipped  string `json:"-"`
+	Dash     string `json:"-,"`
+	internal string
+	Straße   string `json:"straße"`
+}
+
+func TestIndex(t *testing.T) {
+	t.Parallel()
+
+	idx := textra.Extract((*indexStruct)(nil)).Index()
+
+	testCases := []struct {
+		name   string
+		lookup func() (textra.Field, bool)
+		want   string
+	}{
+		{"field", func() (textra.Field, bool) { return idx.Field("Name") }, "Name"},
+		{"missing field", func() (textra.Field, bool) { return idx.Field("name") }, ""},
+		{"by tag", func() (textra.Field, bool) { return idx.ByTag("db", "uid") }, "UserID"},
+		{"by missing tag", func() (textra.Field, bool) { return idx.ByTag("xml", "uid") }, ""},
+		{"json exact", func() (textra.Field, bool) { return idx.JSON("user_id") }, "UserID"},
+		{"json fold", func() (textra.Field, bool) { return idx.JSON("USER_ID") }, "UserID"},
+		{"json field name", func() (textra.Field, bool) { return idx.JSON("name") }, "Name"},
+		{"json tagged wins", func() (textra.Field, bool) { return idx.JSON("Title") }, "Title"},
+		{"json exact untagged", func() (textra.Field, bool) { return idx.JSON("TITLE") }, "TITLE"},
+		{"json ignored", func() (textra.Field, bool) { return idx.JSON("Skipped") }, ""},
+		{"json dash", func() (textra.Field, bool) { return idx.JSON("-") }, "Dash"},
+		{"json unexported", func() (textra.Field, bool) { return idx.JSON("internal") }, ""},
+		{"json embedded", func() (textra.Field, bool) { return idx.JSON("indexEmbedded") }, ""},
+		{"json unicode fold", func() (textra.Field, bool) { return idx.JSON("STRAßE") }, "Straße"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, ok := testCase.lookup()
+			if ok != (testCase.want != "") || f.Name != testCase.want {
+				t.Errorf("lookup = %q, %v, want %q", f.Name, ok, testCase.want)
+			}
+		})
+	}
+}
+
+func TestIndex_Duplicates(t *testing.T) {
+	t.Parallel()
+
+	idx := textra.Struct{
+		{Name: "A", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "dup"}}},
+		{Name: "B", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "dup"}}},
+	}.Index()
+
+	if f, ok := idx.ByTag("json", "dup"); !ok || f.Name != "A" {
+		t.Errorf("ByTag() = %v, %v, want A", f, ok)
+	}
+
+	if f, ok := idx.JSON("dup"); ok {
+		t.Errorf("JSON() = %v, want an ambiguous name to be skipped", f)
+	}
+}
+
+func TestIndex_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	idx := textra.Extract((*indexStruct)(nil)).Index()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if f, ok := idx.JSON("User_Id"); !ok || f.Name != "UserID" {
+				t.Errorf("JSON() = %v, %v", f, ok)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+type indexBase struct {
+	ID      int    `json:"id"`
+	Created string `json:"created"`
+	Note    string
+}
+
+type IndexAudit struct {
+	Note string
+}
+
+type indexDerived struct {
+	indexBase
+	*IndexAudit
+	Created int64  `json:"created"`
+	Name    string `json:"name"`
+}
+
+func TestExtractIndex(t *testing.T) {
+	t.Parallel()
+
+	idx := textra.ExtractIndex((*indexDerived)(nil))
+
+	testCases := []struct {
+		name     string
+		key      string
+		wantName string
+		wantType string
+	}{
+		{"own", "name", "Name", "string"},
+		{"promoted", "id", "ID", "int"},
+		{"promoted fold", "Id", "ID", "int"},
+		{"shallower wins", "created", "Created", "int64"},
+		{"ambiguous", "Note", "", ""},
+		{"embedded", "indexBase", "", ""},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, ok := idx.JSON(testCase.key)
+			if ok != (testCase.wantName != "") || f.Name != testCase.wantName || f.Type != testCase.wantType {
+				t.Errorf("JSON(%q) = %s, %v, want %s(%s)", testCase.key, f, ok, testCase.wantName, testCase.wantType)
+			}
+		})
+	}
+
+	if _, ok := textra.Extract((*indexDerived)(nil)).Index().JSON("id"); ok {
+		t.Errorf("Struct.Index() can't see promoted fields")
+	}
+
+	if idx := textra.ExtractIndex(42); idx != nil {
+		t.Errorf("ExtractIndex(42) = %v, want nil", idx)
+	}
+}
+
+func TestIndex_Fields(t *testing.T) {
+	t.Parallel()
+
+	idx := textra.Extract((*indexStruct)(nil)).Index()
+
+	fields := idx.Fields()
+	fields[1].Name = "changed"
+	fields[1].Tags[0].Value = "changed"
+
+	field, _ := idx.ByTag("json", "user_id")
+	field.Tags[0].Optional = append(field.Tags[0].Optional, "changed")
+
+	if f, ok := idx.Field("UserID"); !ok || f.Tags[0].Value != "user_id" || len(f.Tags[0].Optional) != 0 {
+		t.Errorf("Field() = %v, %v, the index was modified", f, ok)
+	}
+}
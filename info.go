@@ -0,0 +1,188 @@
+package textra
+
+import (
+	"reflect"
+	"strings"
+)
+
+// blankField is a name of blank identifier fields, which are used to attach
+// metadata to a struct, like
+//
+//	_ struct{} `bun:"table:users,alias:u"`
+const blankField = "_"
+
+// StructInfo is a struct with its metadata.
+type StructInfo struct {
+	// Name is the type's name without type arguments, like "User". It's
+	// empty for anonymous structs.
+	Name string `json:"name,omitempty"`
+	// PkgPath is the type's package path, like "example.com/app/models".
+	PkgPath string `json:"pkgPath,omitempty"`
+	// Pointer is true if a pointer to the struct was extracted.
+	Pointer bool `json:"pointer,omitempty"`
+	// TypeArgs holds type arguments of a generic type, like ["int"] for
+	// Box[int].
+	TypeArgs []string `json:"typeArgs,omitempty"`
+	// Methods holds the exported method set of the pointer to the struct,
+	// sorted by name.
+	Methods []Method `json:"methods,omitempty"`
+	// Meta holds tags of blank (_) fields.
+	Meta Tags `json:"meta,omitempty"`
+	// Fields holds all fields except blank ones.
+	Fields Struct `json:"fields"`
+}
+
+// Method describes a single method.
+type Method struct {
+	Name string `json:"name"`
+	// Type is the method's signature without a receiver, like
+	// "func(int) (string, error)".
+	Type string `json:"type"`
+	// PointerReceiver is true if the method is declared on a pointer
+	// receiver, so it's not in the method set of a value.
+	PointerReceiver bool `json:"pointerReceiver,omitempty"`
+}
+
+// ExtractInfo is like Extract, but also returns the type's name, package,
+// methods and struct metadata. It returns false if src is not a struct or a
+// pointer to a struct.
+func ExtractInfo(src interface{}) (StructInfo, bool) {
+	typ := reflect.TypeOf(src)
+	pointer := false
+
+	// If str is a struct pointer
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem() // dereference it
+		pointer = true
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return StructInfo{}, false
+	}
+
+	name, typeArgs := splitTypeArgs(typ.Name())
+	amount := typ.NumField()
+	info := StructInfo{
+		Name:     name,
+		PkgPath:  typ.PkgPath(),
+		Pointer:  pointer,
+		TypeArgs: typeArgs,
+		Methods:  methods(typ),
+		Meta:     make(Tags, 0),
+		Fields:   make(Struct, 0, amount),
+	}
+
+	var f reflect.StructField
+
+	for i := 0; i < amount; i++ {
+		f = typ.Field(i)
+
+		if f.Name == blankField {
+			info.Meta = append(info.Meta, parseTags(f.Tag)...)
+			continue
+		}
+
+		info.Fields = append(info.Fields, Field{
+			Name:     f.Name,
+			Type:     parseType(f.Type),
+			Tags:     parseTags(f.Tag),
+			Embedded: f.Anonymous,
+		})
+	}
+
+	return info, true
+}
+
+// String returns the qualified type name, like "models.User" or
+// "models.Box[int]". Anonymous structs are shown as "struct".
+func (i StructInfo) String() string {
+	if i.Name == "" {
+		return "struct"
+	}
+
+	name := i.Name
+	if i.PkgPath != "" {
+		name = i.PkgPath[strings.LastIndex(i.PkgPath, "/")+1:] + "." + name
+	}
+
+	if len(i.TypeArgs) > 0 {
+		name += "[" + strings.Join(i.TypeArgs, ", ") + "]"
+	}
+
+	return name
+}
+
+// methods returns exported methods of *typ, sorted by name.
+func methods(typ reflect.Type) []Method {
+	ptr := reflect.PtrTo(typ)
+	result := make([]Method, 0, ptr.NumMethod())
+
+	for i := 0; i < ptr.NumMethod(); i++ {
+		m := ptr.Method(i)
+		_, onValue := typ.MethodByName(m.Name)
+
+		result = append(result, Method{
+			Name:            m.Name,
+			Type:            funcSignature(m.Type, 1),
+			PointerReceiver: !onValue,
+		})
+	}
+
+	return result
+}
+
+// Table returns a table name from the metadata. These forms are supported:
+//
+//	_ struct{} `table:"users"`
+//	_ struct{} `bun:"table:users"`
+//	_ struct{} `pg:"users"`
+func (i StructInfo) Table() (string, bool) {
+	if tag, ok := i.Meta.ByName("table"); ok && tag.Value != "" {
+		return tag.Value, true
+	}
+
+	if tag, ok := i.Meta.ByName("bun"); ok {
+		if table, ok := metaOption(tag.Value, "table"); ok {
+			return table, true
+		}
+	}
+
+	if tag, ok := i.Meta.ByName("pg"); ok && tag.Value != "" {
+		return tag.Value, true
+	}
+
+	return "", false
+}
+
+// Alias returns a table alias from the metadata, set by an "alias" option
+// of a tag supported by Table, like
+//
+//	_ struct{} `table:"users,alias:u"`
+func (i StructInfo) Alias() (string, bool) {
+	for _, name := range []string{"table", "bun", "pg"} {
+		tag, ok := i.Meta.ByName(name)
+		if !ok {
+			continue
+		}
+
+		for _, opt := range tag.Optional {
+			if alias, ok := metaOption(opt, "alias"); ok {
+				return alias, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// metaOption returns a value of an option written as "name:value" or
+// "name=value".
+func metaOption(opt, name string) (string, bool) {
+	for _, sep := range []string{":", "="} {
+		if strings.HasPrefix(opt, name+sep) {
+			return strings.TrimSpace(opt[len(name)+1:]), true
+		}
+	}
+
+	return "", false
+}
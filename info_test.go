@@ -0,0 +1,137 @@
+package textra_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ravsii/textra"
+)
+
+func TestExtractInfo(t *testing.T) {
+	type User struct {
+		_    struct{} `table:"users,alias:u"`
+		ID   int      `json:"id"`
+		_    struct{} `comment:"user accounts"`
+		Name string
+	}
+
+	info, ok := textra.ExtractInfo((*User)(nil))
+	if !ok {
+		t.Fatal("ExtractInfo() = false")
+	}
+
+	wantMeta := textra.Tags{{Tag: "table", Value: "users", Optional: []string{"alias:u"}}, {Tag: "comment", Value: "user accounts"}}
+	if !reflect.DeepEqual(info.Meta, wantMeta) {
+		t.Errorf("Meta = %v, want %v", info.Meta, wantMeta)
+	}
+
+	wantFields := textra.Struct{
+		{Name: "ID", Type: "int", Tags: textra.Tags{{Tag: "json", Value: "id"}}},
+		{Name: "Name", Type: "string", Tags: textra.Tags{}},
+	}
+	if !reflect.DeepEqual(info.Fields, wantFields) {
+		t.Errorf("Fields = %v, want %v", info.Fields, wantFields)
+	}
+
+	if got := textra.Extract((*User)(nil)); !reflect.DeepEqual(got, wantFields) {
+		t.Errorf("Extract() = %v, want %v", got, wantFields)
+	}
+
+	if _, ok := textra.ExtractInfo(42); ok {
+		t.Error("ExtractInfo(42) = true")
+	}
+}
+
+type infoUser struct {
+	ID int `json:"id"`
+}
+
+func (infoUser) TableName() string { return "users" }
+
+func (*infoUser) Scan(src interface{}) error { return nil }
+
+func (*infoUser) Format(format string, args ...interface{}) (string, error) { return "", nil }
+
+func TestExtractInfo_Type(t *testing.T) {
+	info, _ := textra.ExtractInfo((*infoUser)(nil))
+
+	want := textra.StructInfo{
+		Name:    "infoUser",
+		PkgPath: "github.com/ravsii/textra_test",
+		Pointer: true,
+		Methods: []textra.Method{
+			{Name: "Format", Type: "func(string, ...interface {}) (string, error)", PointerReceiver: true},
+			{Name: "Scan", Type: "func(interface {}) error", PointerReceiver: true},
+			{Name: "TableName", Type: "func() string"},
+		},
+		Meta:   textra.Tags{},
+		Fields: textra.Struct{{Name: "ID", Type: "int", Tags: textra.Tags{{Tag: "json", Value: "id"}}}},
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("ExtractInfo() = %#v, want %#v", info, want)
+	}
+
+	if got := info.String(); got != "textra_test.infoUser" {
+		t.Errorf("String() = %q", got)
+	}
+
+	if info, _ := textra.ExtractInfo(infoUser{}); info.Pointer {
+		t.Error("ExtractInfo() of a value: Pointer = true")
+	}
+
+	if info, _ := textra.ExtractInfo(struct{}{}); info.String() != "struct" {
+		t.Errorf("String() of an anonymous struct = %q", info.String())
+	}
+}
+
+func TestStructInfo_JSON(t *testing.T) {
+	info := textra.StructInfo{
+		Name:     "Box",
+		PkgPath:  "example.com/app",
+		TypeArgs: []string{"int"},
+		Methods:  []textra.Method{{Name: "Scan", Type: "func(interface {}) error", PointerReceiver: true}},
+		Fields:   textra.Struct{},
+	}
+
+	b, err := json.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"name":"Box","pkgPath":"example.com/app","typeArgs":["int"],` +
+		`"methods":[{"name":"Scan","type":"func(interface {}) error","pointerReceiver":true}],"fields":[]}`
+	if string(b) != want {
+		t.Errorf("json.Marshal() = %s, want %s", b, want)
+	}
+}
+
+func TestStructInfo_Table(t *testing.T) {
+	tests := []struct {
+		name      string
+		meta      textra.Tags
+		wantTable string
+		wantAlias string
+	}{
+		{"table", textra.Tags{{Tag: "table", Value: "users", Optional: []string{"alias:u"}}}, "users", "u"},
+		{"bun", textra.Tags{{Tag: "bun", Value: "table:users", Optional: []string{"alias:u"}}}, "users", "u"},
+		{"pg", textra.Tags{{Tag: "pg", Value: "users", Optional: []string{"alias=u"}}}, "users", "u"},
+		{"alias only", textra.Tags{{Tag: "bun", Value: "", Optional: []string{"alias:u"}}}, "", "u"},
+		{"none", textra.Tags{{Tag: "comment", Value: "users"}}, "", ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			info := textra.StructInfo{Meta: tt.meta}
+
+			if table, ok := info.Table(); table != tt.wantTable || ok != (tt.wantTable != "") {
+				t.Errorf("Table() = %q, %v, want %q", table, ok, tt.wantTable)
+			}
+
+			if alias, ok := info.Alias(); alias != tt.wantAlias || ok != (tt.wantAlias != "") {
+				t.Errorf("Alias() = %q, %v, want %q", alias, ok, tt.wantAlias)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Package naming converts Go identifiers between naming conventions.
+package naming
+
+import (
+	"unicode"
+)
+
+// Snake converts a Go name to snake_case, keeping initialisms together,
+// so "UserID" becomes "user_id" and "HTTPServer" becomes "http_server".
+func Snake(name string) string {
+	runes := []rune(name)
+	snake := make([]rune, 0, len(runes)+4)
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				snake = append(snake, '_')
+			}
+		}
+
+		snake = append(snake, unicode.ToLower(r))
+	}
+
+	return string(snake)
+}
+
+// LowerCamel lower-cases a Go name's leading initialism or letter,
+// so "UserID" becomes "userID" and "HTTPServer" becomes "httpServer".
+func LowerCamel(name string) string {
+	runes := []rune(name)
+
+	for i := 0; i < len(runes) && unicode.IsUpper(runes[i]); i++ {
+		// Keep the last upper-case letter of an initialism, if it starts
+		// the next word.
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+
+		runes[i] = unicode.ToLower(runes[i])
+	}
+
+	return string(runes)
+}
@@ -0,0 +1,30 @@
+package naming_test
+
+import (
+	"testing"
+
+	"github.com/ravsii/textra/internal/naming"
+)
+
+func TestNaming(t *testing.T) {
+	tests := []struct {
+		in, snake, camel string
+	}{
+		{"Name", "name", "name"},
+		{"UserID", "user_id", "userID"},
+		{"HTTPServer", "http_server", "httpServer"},
+		{"ID", "id", "id"},
+		{"Address2Line", "address2_line", "address2Line"},
+		{"already_snake", "already_snake", "already_snake"},
+	}
+
+	for _, tt := range tests {
+		if got := naming.Snake(tt.in); got != tt.snake {
+			t.Errorf("Snake(%s) = %s, want %s", tt.in, got, tt.snake)
+		}
+
+		if got := naming.LowerCamel(tt.in); got != tt.camel {
+			t.Errorf("LowerCamel(%s) = %s, want %s", tt.in, got, tt.camel)
+		}
+	}
+}
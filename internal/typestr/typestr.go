@@ -0,0 +1,90 @@
+// Package typestr parses type strings produced by textra, like
+// "*[]map[string]*time.Time", back into a tree.
+package typestr
+
+import (
+	"strings"
+)
+
+// Kind is a kind of a parsed type.
+type Kind int
+
+// Kinds of parsed types. Everything which is not a pointer, a slice or a map
+// is Named, including "struct", "interface" and "func(...)".
+const (
+	Named Kind = iota
+	Pointer
+	Slice
+	Map
+)
+
+// Type is a parsed type string.
+type Type struct {
+	Kind Kind
+	// Name is set for Named types, like "int", "time.Time" or
+	// "github.com/user/models.User".
+	Name string
+	// Elem is set for pointers, slices and maps.
+	Elem *Type
+	// Key is set for maps.
+	Key *Type
+}
+
+// Parse parses a type string.
+func Parse(s string) *Type {
+	switch {
+	case strings.HasPrefix(s, "*"):
+		return &Type{Kind: Pointer, Elem: Parse(s[1:])}
+	case strings.HasPrefix(s, "[]"):
+		return &Type{Kind: Slice, Elem: Parse(s[2:])}
+	case strings.HasPrefix(s, "map["):
+		depth := 0
+
+		for i := len("map"); i < len(s); i++ {
+			switch s[i] {
+			case '[':
+				depth++
+			case ']':
+				depth--
+				if depth == 0 {
+					return &Type{Kind: Map, Key: Parse(s[len("map["):i]), Elem: Parse(s[i+1:])}
+				}
+			}
+		}
+	}
+
+	return &Type{Kind: Named, Name: s}
+}
+
+// Deref returns t without pointers and true, if there were any.
+func (t *Type) Deref() (*Type, bool) {
+	if t.Kind != Pointer {
+		return t, false
+	}
+
+	for t.Kind == Pointer {
+		t = t.Elem
+	}
+
+	return t, true
+}
+
+// BaseName returns a Named type's name without its package, so
+// "github.com/user/models.User" becomes "User".
+func (t *Type) BaseName() string {
+	name := t.Name
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return name
+}
+
+// Qualified reports whether a Named type has a package, like "time.Time".
+func (t *Type) Qualified() bool {
+	return t.Kind == Named && strings.Contains(t.Name, ".") && !strings.ContainsAny(t.Name, "( ")
+}
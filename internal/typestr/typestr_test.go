@@ -0,0 +1,47 @@
+package typestr_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ravsii/textra/internal/typestr"
+)
+
+func TestParse(t *testing.T) {
+	named := func(name string) *typestr.Type {
+		return &typestr.Type{Kind: typestr.Named, Name: name}
+	}
+
+	tests := []struct {
+		in   string
+		want *typestr.Type
+	}{
+		{"int", named("int")},
+		{"*time.Time", &typestr.Type{Kind: typestr.Pointer, Elem: named("time.Time")}},
+		{"[]*string", &typestr.Type{Kind: typestr.Slice, Elem: &typestr.Type{Kind: typestr.Pointer, Elem: named("string")}}},
+		{"map[string]map[*bool]int", &typestr.Type{
+			Kind: typestr.Map,
+			Key:  named("string"),
+			Elem: &typestr.Type{
+				Kind: typestr.Map,
+				Key:  &typestr.Type{Kind: typestr.Pointer, Elem: named("bool")},
+				Elem: named("int"),
+			},
+		}},
+	}
+
+	for _, tt := range tests {
+		if got := typestr.Parse(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%s) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+
+	user := typestr.Parse("github.com/user/models.User")
+	if user.BaseName() != "User" || !user.Qualified() {
+		t.Errorf("BaseName() = %s, Qualified() = %t", user.BaseName(), user.Qualified())
+	}
+
+	if base, ok := typestr.Parse("**int").Deref(); !ok || base.Name != "int" {
+		t.Errorf("Deref() = %+v, %t", base, ok)
+	}
+}
@@ -0,0 +1,312 @@
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ravsii/textra"
+)
+
+// validateFormats maps validator checks to JSON Schema formats.
+var validateFormats = map[string]string{
+	"email":    "email",
+	"url":      "uri",
+	"uri":      "uri",
+	"uuid":     "uuid",
+	"uuid4":    "uuid",
+	"hostname": "hostname",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+}
+
+// validatePatterns maps validator checks to regular expressions.
+var validatePatterns = map[string]string{
+	"alpha":    "^[a-zA-Z]+$",
+	"alphanum": "^[a-zA-Z0-9]+$",
+	"numeric":  "^[-+]?[0-9]+(?:\\.[0-9]+)?$",
+}
+
+// applyConstraints sets keywords of s from the field's validate tag,
+// description and example tags and then from keyword tags, so the latter win.
+func applyConstraints(s *Schema, field textra.Field, keywordTags []string) error {
+	if tag, ok := field.Tags.ByName("validate"); ok {
+		validate, err := textra.ParseValidateTag(tag)
+		if err != nil {
+			return err
+		}
+
+		if err := applyValidate(s, validate); err != nil {
+			return err
+		}
+	}
+
+	if tag, ok := field.Tags.ByName("description"); ok {
+		s.Description = tag.Raw()
+	}
+
+	if tag, ok := field.Tags.ByName("example"); ok {
+		example, err := typedValue(s, tag.Raw())
+		if err != nil {
+			return fmt.Errorf("example: %v", err)
+		}
+
+		s.Examples = []interface{}{example}
+	}
+
+	for _, name := range keywordTags {
+		if tag, ok := field.Tags.ByName(name); ok {
+			if err := applyKeywordTag(s, tag); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func applyValidate(s *Schema, validate textra.ValidateTag) error {
+	for _, rule := range validate.Rules {
+		// Alternatives can't be expressed by plain keywords.
+		if len(rule.Checks) != 1 {
+			continue
+		}
+
+		if err := applyCheck(s, rule.Checks[0]); err != nil {
+			return err
+		}
+	}
+
+	if validate.Dive == nil {
+		return nil
+	}
+
+	switch {
+	case s.Items != nil:
+		return applyValidate(s.Items, *validate.Dive)
+	case s.AdditionalProperties != nil:
+		return applyValidate(s.AdditionalProperties, *validate.Dive)
+	}
+
+	return nil
+}
+
+func applyCheck(s *Schema, check textra.ValidateCheck) error {
+	if format, ok := validateFormats[check.Name]; ok {
+		s.Format = format
+		return nil
+	}
+
+	if pattern, ok := validatePatterns[check.Name]; ok {
+		s.Pattern = pattern
+		return nil
+	}
+
+	switch check.Name {
+	case "min", "gte":
+		return setBound(s, check.Param, true, false)
+	case "max", "lte":
+		return setBound(s, check.Param, false, false)
+	case "gt":
+		return setBound(s, check.Param, true, true)
+	case "lt":
+		return setBound(s, check.Param, false, true)
+	case "len":
+		if err := setBound(s, check.Param, true, false); err != nil {
+			return err
+		}
+
+		return setBound(s, check.Param, false, false)
+	case "oneof":
+		enum, err := typedValues(s, check.Params())
+		if err != nil {
+			return err
+		}
+
+		s.Enum = enum
+	case "unique":
+		s.UniqueItems = true
+	}
+
+	return nil
+}
+
+// setBound sets a lower or an upper bound, which is a length for strings,
+// arrays and objects, or a value for numbers.
+func setBound(s *Schema, param string, lower, exclusive bool) error {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return fmt.Errorf("bad bound %q: %v", param, err)
+	}
+
+	if len(s.Type) == 0 {
+		return nil
+	}
+
+	switch s.Type[0] {
+	case "integer", "number":
+		switch {
+		case lower && exclusive:
+			s.ExclusiveMinimum = floatPtr(value)
+		case lower:
+			s.Minimum = floatPtr(value)
+		case exclusive:
+			s.ExclusiveMaximum = floatPtr(value)
+		default:
+			s.Maximum = floatPtr(value)
+		}
+
+		return nil
+	}
+
+	// Lengths are integers, so exclusive bounds are shifted by one.
+	length := int(math.Floor(value))
+
+	switch {
+	case lower && exclusive:
+		length++
+	case exclusive:
+		length--
+	}
+
+	var lo, hi **int
+
+	switch s.Type[0] {
+	case "string":
+		lo, hi = &s.MinLength, &s.MaxLength
+	case "array":
+		lo, hi = &s.MinItems, &s.MaxItems
+	case "object":
+		lo, hi = &s.MinProperties, &s.MaxProperties
+	default:
+		return nil
+	}
+
+	if lower {
+		*lo = intPtr(length)
+	} else {
+		*hi = intPtr(length)
+	}
+
+	return nil
+}
+
+// applyKeywordTag applies keywords listed in a tag, like
+//
+//	`jsonschema:"minLength=3,format=email,enum=a b c,readOnly"`.
+//
+// Values can't contain commas, enum values are separated by spaces.
+func applyKeywordTag(s *Schema, tag textra.Tag) error {
+	for _, opt := range strings.Split(tag.Raw(), ",") {
+		split := strings.SplitN(opt, "=", 2)
+		key := strings.TrimSpace(split[0])
+		value := ""
+
+		if len(split) == 2 {
+			value = strings.TrimSpace(split[1])
+		}
+
+		var err error
+
+		switch key {
+		case "title":
+			s.Title = value
+		case "description":
+			s.Description = value
+		case "format":
+			s.Format = value
+		case "pattern":
+			s.Pattern = value
+		case "enum":
+			s.Enum, err = typedValues(s, strings.Fields(value))
+		case "default":
+			s.Default, err = typedValue(s, value)
+		case "example":
+			var example interface{}
+
+			example, err = typedValue(s, value)
+			s.Examples = append(s.Examples, example)
+		case "readOnly":
+			s.ReadOnly = true
+		case "writeOnly":
+			s.WriteOnly = true
+		case "minimum":
+			s.Minimum, err = parseFloat(value)
+		case "maximum":
+			s.Maximum, err = parseFloat(value)
+		case "exclusiveMinimum":
+			s.ExclusiveMinimum, err = parseFloat(value)
+		case "exclusiveMaximum":
+			s.ExclusiveMaximum, err = parseFloat(value)
+		case "minLength":
+			s.MinLength, err = parseInt(value)
+		case "maxLength":
+			s.MaxLength, err = parseInt(value)
+		case "minItems":
+			s.MinItems, err = parseInt(value)
+		case "maxItems":
+			s.MaxItems, err = parseInt(value)
+		case "uniqueItems":
+			s.UniqueItems = true
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s tag %s: %v", tag.Tag, key, err)
+		}
+	}
+
+	return nil
+}
+
+// typedValues converts values to the schema's type, so "1" becomes 1 for
+// integers.
+func typedValues(s *Schema, values []string) ([]interface{}, error) {
+	typed := make([]interface{}, 0, len(values))
+
+	for _, v := range values {
+		t, err := typedValue(s, v)
+		if err != nil {
+			return nil, err
+		}
+
+		typed = append(typed, t)
+	}
+
+	return typed, nil
+}
+
+func typedValue(s *Schema, value string) (interface{}, error) {
+	if len(s.Type) == 0 {
+		return value, nil
+	}
+
+	switch s.Type[0] {
+	case "integer":
+		return strconv.ParseInt(value, 10, 64)
+	case "number":
+		return strconv.ParseFloat(value, 64)
+	case "boolean":
+		return strconv.ParseBool(value)
+	default:
+		return value, nil
+	}
+}
+
+func parseFloat(value string) (*float64, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func parseInt(value string) (*int, error) {
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &i, nil
+}
@@ -0,0 +1,476 @@
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ravsii/textra"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// DefsPrefix is a default prefix of references to named types.
+const DefsPrefix = "#/$defs/"
+
+// Reflector generates schemas from Go types.
+// The zero value is ready to use.
+type Reflector struct {
+	// RefPrefix is prepended to names of referenced types.
+	// DefsPrefix is used if it's empty.
+	RefPrefix string
+	// NullablePointers makes pointer fields accept null, since
+	// encoding/json encodes nil pointers as null.
+	NullablePointers bool
+	// KeywordTags lists tags which hold schema keywords, like
+	//	`jsonschema:"minLength=3,readOnly"`.
+	// Only "jsonschema" is used if it's empty.
+	KeywordTags []string
+}
+
+// Reflect is a shortcut for (&Reflector{}).Reflect(v).
+func Reflect(v interface{}) (*Schema, error) {
+	return (&Reflector{}).Reflect(v)
+}
+
+// Reflect returns a schema of v's type, which is usually a struct
+// (or a pointer to a struct).
+//
+// Property names come from json tags, fields without "omitempty" are
+// required, constraints come from validate and jsonschema tags.
+// Named struct types are placed into $defs and referenced, so each one is
+// generated once and recursive types are supported. The root type itself is
+// referenced as "#".
+func (r *Reflector) Reflect(v interface{}) (*Schema, error) {
+	typ := reflect.TypeOf(v)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil {
+		return nil, fmt.Errorf("jsonschema: can't reflect %v", v)
+	}
+
+	g := r.newGenerator(typ)
+
+	var (
+		schema *Schema
+		err    error
+	)
+
+	if typ.Kind() == reflect.Struct && !isSpecial(typ) {
+		schema, err = g.structSchema(typ)
+	} else {
+		schema, err = g.schemaFor(typ)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if schema == nil {
+		return nil, fmt.Errorf("jsonschema: unsupported type %s", typ)
+	}
+
+	schema.Schema = Draft
+	if len(g.defs) > 0 {
+		schema.Defs = g.defs
+	}
+
+	return schema, nil
+}
+
+// Definitions returns schemas of the given named struct types (and all the
+// named structs they use), keyed by their names, so they can be placed
+// under RefPrefix. Unlike Reflect, root types are referenced by their names.
+func (r *Reflector) Definitions(values ...interface{}) (map[string]*Schema, error) {
+	g := r.newGenerator(nil)
+
+	for _, v := range values {
+		typ := reflect.TypeOf(v)
+		for typ != nil && typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		if typ == nil || typ.Kind() != reflect.Struct || typ.Name() == "" || isSpecial(typ) {
+			return nil, fmt.Errorf("jsonschema: %v is not a named struct", typ)
+		}
+
+		if _, err := g.ref(typ); err != nil {
+			return nil, err
+		}
+	}
+
+	return g.defs, nil
+}
+
+func (r *Reflector) newGenerator(root reflect.Type) *generator {
+	g := &generator{
+		Reflector: *r,
+		root:      root,
+		names:     make(map[reflect.Type]string),
+		taken:     make(map[string]bool),
+		defs:      make(map[string]*Schema),
+	}
+
+	if g.RefPrefix == "" {
+		g.RefPrefix = DefsPrefix
+	}
+
+	if len(g.KeywordTags) == 0 {
+		g.KeywordTags = []string{"jsonschema"}
+	}
+
+	return g
+}
+
+type generator struct {
+	// Reflector is a copy with defaults filled in.
+	Reflector
+	root reflect.Type
+	// names holds $defs names of already seen named structs.
+	names map[reflect.Type]string
+	taken map[string]bool
+	defs  map[string]*Schema
+}
+
+// schemaFor returns a schema of typ, or nil if it can't be represented
+// in JSON, like channels or functions.
+func (g *generator) schemaFor(typ reflect.Type) (*Schema, error) {
+	if typ.Kind() == reflect.Ptr {
+		return g.schemaFor(typ.Elem())
+	}
+
+	if special, ok := specialSchema(typ); ok {
+		return special, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: Types{"integer"}}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}, nil
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}, nil
+	case reflect.Slice, reflect.Array:
+		if typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: Types{"string"}, ContentEncoding: "base64"}, nil
+		}
+
+		items, err := g.schemaFor(typ.Elem())
+		if err != nil || items == nil {
+			return nil, err
+		}
+
+		schema := &Schema{Type: Types{"array"}, Items: items}
+		if typ.Kind() == reflect.Array {
+			schema.MinItems, schema.MaxItems = intPtr(typ.Len()), intPtr(typ.Len())
+		}
+
+		return schema, nil
+	case reflect.Map:
+		values, err := g.schemaFor(typ.Elem())
+		if err != nil || values == nil {
+			return nil, err
+		}
+
+		return &Schema{Type: Types{"object"}, AdditionalProperties: values}, nil
+	case reflect.Struct:
+		if typ.Name() == "" {
+			return g.structSchema(typ)
+		}
+
+		return g.ref(typ)
+	case reflect.Interface:
+		return &Schema{}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// ref returns a reference to a named struct, generating its definition first,
+// if needed.
+func (g *generator) ref(typ reflect.Type) (*Schema, error) {
+	if g.root != nil && typ == g.root {
+		return &Schema{Ref: "#"}, nil
+	}
+
+	if name, ok := g.names[typ]; ok {
+		return &Schema{Ref: g.RefPrefix + name}, nil
+	}
+
+	name := typ.Name()
+	for i := 2; g.taken[name]; i++ {
+		name = typ.Name() + strconv.Itoa(i)
+	}
+
+	g.names[typ] = name
+	g.taken[name] = true
+
+	def, err := g.structSchema(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	g.defs[name] = def
+
+	return &Schema{Ref: g.RefPrefix + name}, nil
+}
+
+func (g *generator) structSchema(typ reflect.Type) (*Schema, error) {
+	schema := &Schema{Type: Types{"object"}}
+
+	for _, f := range jsonFields(typ) {
+		prop, err := g.schemaFor(f.typ)
+		if err != nil {
+			return nil, err
+		}
+
+		if prop == nil {
+			continue
+		}
+
+		if f.asString {
+			prop = &Schema{Type: Types{"string"}}
+		}
+
+		if err := applyConstraints(prop, f.field, g.KeywordTags); err != nil {
+			return nil, fmt.Errorf("jsonschema: %s.%s: %v", typ, f.field.Name, err)
+		}
+
+		if g.NullablePointers && f.typ.Kind() == reflect.Ptr {
+			prop = nullable(prop)
+		}
+
+		schema.Properties = append(schema.Properties, Property{Name: f.name, Schema: prop})
+		if !f.omitEmpty {
+			schema.Required = append(schema.Required, f.name)
+		}
+	}
+
+	return schema, nil
+}
+
+// nullable makes s accept null.
+func nullable(s *Schema) *Schema {
+	if len(s.Type) > 0 {
+		s.Type = append(s.Type, "null")
+		return s
+	}
+
+	// References and schemas without a type are wrapped. Annotations are
+	// kept on the wrapper.
+	wrapper := &Schema{
+		Title:       s.Title,
+		Description: s.Description,
+		Default:     s.Default,
+		Examples:    s.Examples,
+		ReadOnly:    s.ReadOnly,
+		WriteOnly:   s.WriteOnly,
+		AnyOf:       []*Schema{s, {Type: Types{"null"}}},
+	}
+
+	s.Title, s.Description, s.Default, s.Examples = "", "", nil, nil
+	s.ReadOnly, s.WriteOnly = false, false
+
+	return wrapper
+}
+
+// specialSchema returns schemas for types with custom JSON encoding.
+func specialSchema(typ reflect.Type) (*Schema, bool) {
+	switch {
+	case typ == timeType:
+		return &Schema{Type: Types{"string"}, Format: "date-time"}, true
+	case implements(typ, jsonMarshalerType):
+		return &Schema{}, true
+	case implements(typ, textMarshalerType):
+		return &Schema{Type: Types{"string"}}, true
+	}
+
+	return nil, false
+}
+
+func isSpecial(typ reflect.Type) bool {
+	_, ok := specialSchema(typ)
+	return ok
+}
+
+func implements(typ, iface reflect.Type) bool {
+	return typ.Implements(iface) || (typ.Kind() != reflect.Ptr && reflect.PtrTo(typ).Implements(iface))
+}
+
+// jsonField is a field as encoding/json sees it.
+type jsonField struct {
+	name      string
+	field     textra.Field
+	typ       reflect.Type
+	omitEmpty bool
+	asString  bool
+	tagged    bool
+	depth     int
+}
+
+// jsonFields returns fields of typ, including promoted ones, resolved by
+// encoding/json rules.
+func jsonFields(typ reflect.Type) []jsonField {
+	all := collectFields(typ, 0, map[reflect.Type]bool{})
+
+	byName := make(map[string][]int)
+	order := make([]string, 0, len(all))
+
+	for i, f := range all {
+		if _, ok := byName[f.name]; !ok {
+			order = append(order, f.name)
+		}
+
+		byName[f.name] = append(byName[f.name], i)
+	}
+
+	fields := make([]jsonField, 0, len(order))
+
+	for _, name := range order {
+		if f, ok := dominantField(all, byName[name]); ok {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields
+}
+
+// dominantField picks a field which hides the others with the same name:
+// the shallowest one, or the only tagged one on the same depth.
+func dominantField(all []jsonField, indexes []int) (jsonField, bool) {
+	minDepth := all[indexes[0]].depth
+	for _, i := range indexes {
+		if all[i].depth < minDepth {
+			minDepth = all[i].depth
+		}
+	}
+
+	var candidates, tagged []jsonField
+
+	for _, i := range indexes {
+		if all[i].depth != minDepth {
+			continue
+		}
+
+		candidates = append(candidates, all[i])
+		if all[i].tagged {
+			tagged = append(tagged, all[i])
+		}
+	}
+
+	switch {
+	case len(candidates) == 1:
+		return candidates[0], true
+	case len(tagged) == 1:
+		return tagged[0], true
+	default:
+		return jsonField{}, false
+	}
+}
+
+func collectFields(typ reflect.Type, depth int, visited map[reflect.Type]bool) []jsonField {
+	if visited[typ] {
+		return nil
+	}
+
+	visited[typ] = true
+	defer delete(visited, typ)
+
+	fields := make([]jsonField, 0, typ.NumField())
+	// Field names are unique within a struct, fields Extract skips, like
+	// blank ones, aren't found.
+	extracted := textra.Extract(reflect.Zero(reflect.PtrTo(typ)).Interface()).Index()
+
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+
+		field, ok := extracted.Field(sf.Name)
+		if !ok {
+			continue
+		}
+
+		tag, tagged := field.Tags.ByName("json")
+
+		if tagged && tag.Ignored() && len(tag.Optional) == 0 {
+			continue
+		}
+
+		if sf.Anonymous {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			if !isExported(sf.Name) && ft.Kind() != reflect.Struct {
+				continue
+			}
+
+			if tag.Value == "" && ft.Kind() == reflect.Struct && !isSpecial(ft) {
+				fields = append(fields, collectFields(ft, depth+1, visited)...)
+				continue
+			}
+		} else if !isExported(sf.Name) {
+			continue
+		}
+
+		name := tag.Value
+		if name == "" {
+			name = sf.Name
+		}
+
+		fields = append(fields, jsonField{
+			name:      name,
+			field:     field,
+			typ:       sf.Type,
+			omitEmpty: tag.OmitEmpty(),
+			asString:  tag.HasOption("string") && isStringable(sf.Type),
+			tagged:    tag.Value != "",
+			depth:     depth,
+		})
+	}
+
+	return fields
+}
+
+// isStringable reports whether the ",string" option applies to typ.
+func isStringable(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+func isExported(name string) bool {
+	r, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(r)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
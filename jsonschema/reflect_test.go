@@ -0,0 +1,201 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ravsii/textra/jsonschema"
+)
+
+type Base struct {
+	ID      int       `json:"id"`
+	Created time.Time `json:"created"`
+}
+
+type Address struct {
+	City string `json:"city" validate:"required,min=2"`
+}
+
+type Node struct {
+	Value    string  `json:"value"`
+	Children []*Node `json:"children,omitempty"`
+}
+
+type User struct {
+	Base
+	Name     string            `json:"name"               validate:"required,min=3,max=10"`
+	Email    string            `json:"email,omitempty"    validate:"email"`
+	Role     string            `json:"role"               validate:"oneof=admin user"`
+	Age      int               `json:"age,omitempty"      validate:"gte=0,lt=150"`
+	Score    int               `json:"score,string"`
+	Tags     []string          `json:"tags"               validate:"unique,dive,alpha"`
+	Labels   map[string]string `json:"labels,omitempty"   jsonschema:"description=Free-form labels"`
+	Home     *Address          `json:"home,omitempty"`
+	Work     Address           `json:"work"`
+	Tree     Node              `json:"tree"`
+	Parent   *User             `json:"parent,omitempty"`
+	Level    int               `json:"level"              jsonschema:"enum=1 2 3,default=1"`
+	Data     []byte            `json:"data,omitempty"`
+	Hidden   string            `json:"-"`
+	Callback func()            `json:"callback"`
+	private  int
+}
+
+func TestReflect(t *testing.T) {
+	schema, err := jsonschema.Reflect(&User{})
+	if err != nil {
+		t.Fatalf("Reflect() error = %v", err)
+	}
+
+	got, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	want := `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object",` +
+		`"properties":{` +
+		`"id":{"type":"integer"},` +
+		`"created":{"type":"string","format":"date-time"},` +
+		`"name":{"type":"string","minLength":3,"maxLength":10},` +
+		`"email":{"type":"string","format":"email"},` +
+		`"role":{"type":"string","enum":["admin","user"]},` +
+		`"age":{"type":"integer","minimum":0,"exclusiveMaximum":150},` +
+		`"score":{"type":"string"},` +
+		`"tags":{"type":"array","items":{"type":"string","pattern":"^[a-zA-Z]+$"},"uniqueItems":true},` +
+		`"labels":{"description":"Free-form labels","type":"object","additionalProperties":{"type":"string"}},` +
+		`"home":{"$ref":"#/$defs/Address"},` +
+		`"work":{"$ref":"#/$defs/Address"},` +
+		`"tree":{"$ref":"#/$defs/Node"},` +
+		`"parent":{"$ref":"#"},` +
+		`"level":{"type":"integer","enum":[1,2,3],"default":1},` +
+		`"data":{"type":"string","contentEncoding":"base64"}},` +
+		`"required":["id","created","name","role","score","tags","work","tree","level"],` +
+		`"$defs":{` +
+		`"Address":{"type":"object","properties":{"city":{"type":"string","minLength":2}},"required":["city"]},` +
+		`"Node":{"type":"object","properties":{"value":{"type":"string"},` +
+		`"children":{"type":"array","items":{"$ref":"#/$defs/Node"}}},"required":["value"]}}}`
+
+	if string(got) != want {
+		t.Errorf("Reflect() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestReflectBlankFields(t *testing.T) {
+	type Table struct {
+		_    struct{} `table:"t"`
+		Name string   `json:"name"`
+		Age  int      `json:"age"`
+	}
+
+	schema, err := jsonschema.Reflect(Table{})
+	if err != nil {
+		t.Fatalf("Reflect() error = %v", err)
+	}
+
+	got, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	want := `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object",` +
+		`"properties":{"name":{"type":"string"},"age":{"type":"integer"}},"required":["name","age"]}`
+	if string(got) != want {
+		t.Errorf("Reflect() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestReflectFreeText(t *testing.T) {
+	type Account struct {
+		Owner string `json:"owner" description:"A user, or a bot" example:"Jane, Doe"`
+	}
+
+	schema, err := jsonschema.Reflect(Account{})
+	if err != nil {
+		t.Fatalf("Reflect() error = %v", err)
+	}
+
+	owner, ok := schema.Properties.Get("owner")
+	if !ok {
+		t.Fatal("Reflect() has no owner property")
+	}
+
+	got, err := json.Marshal(owner)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	want := `{"description":"A user, or a bot","type":"string","examples":["Jane, Doe"]}`
+	if string(got) != want {
+		t.Errorf("Reflect() = %s, want %s", got, want)
+	}
+}
+
+func TestReflectErrors(t *testing.T) {
+	type BadBound struct {
+		Name string `json:"name" validate:"min=x"`
+	}
+
+	type BadTag struct {
+		Name string `json:"name" validate:"dive,keys"`
+	}
+
+	testCases := []struct {
+		name  string
+		input interface{}
+	}{
+		{"nil", nil},
+		{"unsupported", make(chan int)},
+		{"bad bound", BadBound{}},
+		{"bad validate tag", BadTag{}},
+	}
+
+	for _, testCase := range testCases {
+		if _, err := jsonschema.Reflect(testCase.input); err == nil {
+			t.Errorf("%s: expected an error", testCase.name)
+		}
+	}
+}
+
+func TestReflectorDefinitions(t *testing.T) {
+	type Account struct {
+		ID    int      `json:"id"              openapi:"readOnly"`
+		Pass  string   `json:"pass"            openapi:"writeOnly"`
+		Nick  *string  `json:"nick,omitempty"  description:"Public name" example:"neo"`
+		Owner *Address `json:"owner,omitempty" description:"Billing address"`
+		Self  *Account `json:"self,omitempty"`
+	}
+
+	r := jsonschema.Reflector{
+		RefPrefix:        "#/components/schemas/",
+		NullablePointers: true,
+		KeywordTags:      []string{"jsonschema", "openapi"},
+	}
+
+	defs, err := r.Definitions(&Account{})
+	if err != nil {
+		t.Fatalf("Definitions() error = %v", err)
+	}
+
+	got, err := json.Marshal(defs)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	want := `{"Account":{"type":"object","properties":{` +
+		`"id":{"type":"integer","readOnly":true},` +
+		`"pass":{"type":"string","writeOnly":true},` +
+		`"nick":{"description":"Public name","type":["string","null"],"examples":["neo"]},` +
+		`"owner":{"description":"Billing address","anyOf":[{"$ref":"#/components/schemas/Address"},{"type":"null"}]},` +
+		`"self":{"anyOf":[{"$ref":"#/components/schemas/Account"},{"type":"null"}]}},` +
+		`"required":["id","pass"]},` +
+		`"Address":{"type":"object","properties":{"city":{"type":"string","minLength":2}},"required":["city"]}}`
+
+	if string(got) != want {
+		t.Errorf("Definitions() =\n%s\nwant\n%s", got, want)
+	}
+
+	if _, err := r.Definitions(struct{}{}); err == nil {
+		t.Errorf("Definitions() should fail for anonymous structs")
+	}
+}
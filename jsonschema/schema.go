@@ -0,0 +1,115 @@
+// Package jsonschema generates JSON Schema (Draft 2020-12) documents from Go
+// types, using textra to read json, validate and jsonschema tags.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Draft is the dialect of generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document or a subschema.
+// Only keywords used by the generator are listed.
+type Schema struct {
+	Schema      string        `json:"$schema,omitempty"`
+	Ref         string        `json:"$ref,omitempty"`
+	Title       string        `json:"title,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Type        Types         `json:"type,omitempty"`
+	Format      string        `json:"format,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`
+	Default     interface{}   `json:"default,omitempty"`
+	Examples    []interface{} `json:"examples,omitempty"`
+	ReadOnly    bool          `json:"readOnly,omitempty"`
+	WriteOnly   bool          `json:"writeOnly,omitempty"`
+
+	Pattern          string   `json:"pattern,omitempty"`
+	MinLength        *int     `json:"minLength,omitempty"`
+	MaxLength        *int     `json:"maxLength,omitempty"`
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+	ContentEncoding  string   `json:"contentEncoding,omitempty"`
+
+	Items       *Schema `json:"items,omitempty"`
+	MinItems    *int    `json:"minItems,omitempty"`
+	MaxItems    *int    `json:"maxItems,omitempty"`
+	UniqueItems bool    `json:"uniqueItems,omitempty"`
+
+	Properties           Properties `json:"properties,omitempty"`
+	Required             []string   `json:"required,omitempty"`
+	AdditionalProperties *Schema    `json:"additionalProperties,omitempty"`
+	MinProperties        *int       `json:"minProperties,omitempty"`
+	MaxProperties        *int       `json:"maxProperties,omitempty"`
+
+	AnyOf []*Schema          `json:"anyOf,omitempty"`
+	Defs  map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Types holds one or more JSON types, like "string" or "null".
+// A single type is marshaled as a string, multiple types as an array.
+type Types []string
+
+// MarshalJSON implements json.Marshaler.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+
+	return json.Marshal([]string(t))
+}
+
+// Property is a single named property of an object schema.
+type Property struct {
+	Name   string
+	Schema *Schema
+}
+
+// Properties holds properties in the order of struct fields.
+// It's marshaled as a JSON object.
+type Properties []Property
+
+// Get returns a property's schema by its name.
+func (p Properties) Get(name string) (*Schema, bool) {
+	for _, prop := range p {
+		if prop.Name == name {
+			return prop.Schema, true
+		}
+	}
+
+	return nil, false
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p Properties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, prop := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		name, err := json.Marshal(prop.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		schema, err := json.Marshal(prop.Schema)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(schema)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
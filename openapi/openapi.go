@@ -0,0 +1,81 @@
+// Package openapi exports Go types as OpenAPI 3.1 component schemas,
+// built on top of the jsonschema package.
+//
+// Besides json, validate and jsonschema tags, fields may have description
+// and example tags and an openapi tag with schema keywords, like
+//
+//	`openapi:"readOnly"`.
+package openapi
+
+import (
+	"encoding/json"
+
+	"github.com/ravsii/textra/jsonschema"
+)
+
+// Version is the OpenAPI version of generated documents.
+const Version = "3.1.0"
+
+// SchemasPrefix is a prefix of references to component schemas.
+const SchemasPrefix = "#/components/schemas/"
+
+// Document is an OpenAPI document which has only component schemas.
+type Document struct {
+	OpenAPI    string     `json:"openapi"`
+	Info       Info       `json:"info"`
+	Components Components `json:"components"`
+}
+
+// Info is an info object of a Document.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Components holds reusable schemas, keyed by type names.
+type Components struct {
+	Schemas map[string]*jsonschema.Schema `json:"schemas"`
+}
+
+// Schemas returns component schemas of the given named struct types and all
+// the named structs they use. Pointer fields are nullable and time.Time is
+// a date-time string.
+func Schemas(values ...interface{}) (map[string]*jsonschema.Schema, error) {
+	r := jsonschema.Reflector{
+		RefPrefix:        SchemasPrefix,
+		NullablePointers: true,
+		KeywordTags:      []string{"jsonschema", "openapi"},
+	}
+
+	return r.Definitions(values...)
+}
+
+// NewDocument returns a Document with component schemas of values.
+// See Schemas.
+func NewDocument(title, version string, values ...interface{}) (*Document, error) {
+	schemas, err := Schemas(values...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Document{
+		OpenAPI:    Version,
+		Info:       Info{Title: title, Version: version},
+		Components: Components{Schemas: schemas},
+	}, nil
+}
+
+// JSON returns the document as indented JSON.
+func (d *Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// YAML returns the document as YAML.
+func (d *Document) YAML() ([]byte, error) {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonToYAML(b)
+}
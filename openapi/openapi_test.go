@@ -0,0 +1,105 @@
+package openapi_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ravsii/textra/openapi"
+)
+
+type Pet struct {
+	ID        int        `json:"id"                  openapi:"readOnly"`
+	Name      string     `json:"name"                description:"Pet's name" example:"Rex"`
+	Tag       *string    `json:"tag,omitempty"`
+	Secret    string     `json:"secret,omitempty"    openapi:"writeOnly"`
+	Born      time.Time  `json:"born"`
+	Owner     *Owner     `json:"owner,omitempty"`
+	Kind      string     `json:"kind"                validate:"oneof=cat dog"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type Owner struct {
+	Name string `json:"name"`
+}
+
+func TestDocument(t *testing.T) {
+	doc, err := openapi.NewDocument("Pets", "1.0.0", (*Pet)(nil))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+
+	got, err := doc.YAML()
+	if err != nil {
+		t.Fatalf("YAML() error = %v", err)
+	}
+
+	want := `openapi: "3.1.0"
+info:
+  title: Pets
+  version: "1.0.0"
+components:
+  schemas:
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+      required:
+        - name
+    Pet:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          description: "Pet's name"
+          type: string
+          examples:
+            - Rex
+        tag:
+          type:
+            - string
+            - "null"
+        secret:
+          type: string
+          writeOnly: true
+        born:
+          type: string
+          format: date-time
+        owner:
+          anyOf:
+            -
+              $ref: "#/components/schemas/Owner"
+            -
+              type: "null"
+        kind:
+          type: string
+          enum:
+            - cat
+            - dog
+        updated_at:
+          type:
+            - string
+            - "null"
+          format: date-time
+      required:
+        - id
+        - name
+        - born
+        - kind
+`
+
+	if string(got) != want {
+		t.Errorf("YAML() =\n%s\nwant\n%s", got, want)
+	}
+
+	js, err := doc.JSON()
+	if err != nil || len(js) == 0 {
+		t.Errorf("JSON() error = %v", err)
+	}
+
+	if _, err := openapi.NewDocument("Bad", "1", 42); err == nil {
+		t.Errorf("NewDocument() should fail for non-structs")
+	}
+}
@@ -0,0 +1,167 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// node is a decoded JSON value which keeps the order of object keys.
+type node struct {
+	// keys and values are set for objects.
+	keys   []string
+	values []*node
+	// items is set for arrays.
+	items []*node
+	// scalar holds JSON of strings, numbers, booleans and null.
+	scalar interface{}
+
+	isObject, isArray bool
+}
+
+// plainYAML matches strings which can be written without quotes.
+var plainYAML = regexp.MustCompile(`^[A-Za-z_/$][A-Za-z0-9_ ./$()-]*$`)
+
+// yamlReserved holds plain strings which would be read as non-strings.
+var yamlReserved = map[string]bool{
+	"true": true, "false": true, "null": true, "yes": true, "no": true,
+	"on": true, "off": true, "y": true, "n": true, "~": true,
+}
+
+// jsonToYAML converts JSON to YAML, keeping the order of object keys.
+func jsonToYAML(b []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	root, err := decodeNode(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	writeNode(&buf, root, 0)
+
+	return buf.Bytes(), nil
+}
+
+func decodeNode(dec *json.Decoder) (*node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return &node{scalar: tok}, nil
+	}
+
+	n := &node{isObject: delim == '{', isArray: delim == '['}
+
+	for dec.More() {
+		if n.isArray {
+			item, err := decodeNode(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			n.items = append(n.items, item)
+
+			continue
+		}
+
+		keyTok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok := keyTok.(string)
+		if !ok {
+			return nil, errors.New("openapi: object key is not a string")
+		}
+
+		value, err := decodeNode(dec)
+		if err != nil {
+			return nil, err
+		}
+
+		n.keys = append(n.keys, key)
+		n.values = append(n.values, value)
+	}
+
+	// Closing delimiter.
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// writeNode writes n as a block at the given indentation level.
+// It's expected to be called at the start of a line.
+func writeNode(buf *bytes.Buffer, n *node, indent int) {
+	prefix := strings.Repeat("  ", indent)
+
+	switch {
+	case n.isObject && len(n.keys) > 0:
+		for i, key := range n.keys {
+			buf.WriteString(prefix + yamlScalar(key) + ":")
+			writeValue(buf, n.values[i], indent)
+		}
+	case n.isArray && len(n.items) > 0:
+		for _, item := range n.items {
+			buf.WriteString(prefix + "-")
+			writeValue(buf, item, indent)
+		}
+	default:
+		buf.WriteString(prefix + inlineValue(n) + "\n")
+	}
+}
+
+// writeValue writes a value which follows a "key:" or a "-".
+func writeValue(buf *bytes.Buffer, n *node, indent int) {
+	if (n.isObject && len(n.keys) > 0) || (n.isArray && len(n.items) > 0) {
+		buf.WriteString("\n")
+		writeNode(buf, n, indent+1)
+
+		return
+	}
+
+	buf.WriteString(" " + inlineValue(n) + "\n")
+}
+
+// inlineValue returns scalars, empty objects and empty arrays.
+func inlineValue(n *node) string {
+	switch {
+	case n.isObject:
+		return "{}"
+	case n.isArray:
+		return "[]"
+	}
+
+	switch v := n.scalar.(type) {
+	case string:
+		return yamlScalar(v)
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return "null"
+	}
+}
+
+// yamlScalar returns s as is, if it's safe to use as a plain scalar,
+// or quoted otherwise. JSON escapes are valid in YAML double-quoted strings.
+func yamlScalar(s string) string {
+	if plainYAML.MatchString(s) && !yamlReserved[strings.ToLower(s)] && !strings.HasSuffix(s, " ") {
+		return s
+	}
+
+	b, _ := json.Marshal(s)
+
+	return string(b)
+}
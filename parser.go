@@ -3,10 +3,13 @@ package textra
 import (
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
-var tagRegexp = regexp.MustCompile(`(\w+:\"[^\"]+\")`)
+// tagRegexp matches a single tag. Names may contain dots for profiles,
+// like `validate.create:"required"`.
+var tagRegexp = regexp.MustCompile(`([\w.]+:\"(?:[^\"\\]|\\.)+\")`)
 
 func parseTags(tag reflect.StructTag) Tags {
 	tags := tagRegexp.FindAllString(string(tag), -1)
@@ -20,14 +23,25 @@ func parseTags(tag reflect.StructTag) Tags {
 }
 
 func parseTag(tagStr string) Tag {
-	split := strings.Split(tagStr, ":")
-	v := strings.Trim(split[1], "\"")
-	vs := strings.Split(v, ",")
-	value := strings.TrimSpace(vs[0])
+	// Only the first colon separates the name, values like gorm's
+	// "column:name;type:text" may contain more.
+	split := strings.SplitN(tagStr, ":", 2)
+	v, err := strconv.Unquote(split[1])
+	if err != nil {
+		v = strings.Trim(split[1], "\"")
+	}
+
+	return newTag(split[0], v)
+}
+
+// newTag splits value into a tag's value and options. The value is kept in
+// Text if splitting loses spaces around commas.
+func newTag(name, value string) Tag {
+	vs := strings.Split(value, ",")
 
 	tag := Tag{
-		Tag:   split[0],
-		Value: value,
+		Tag:   name,
+		Value: strings.TrimSpace(vs[0]),
 	}
 
 	if len(vs) > 1 {
@@ -37,15 +51,21 @@ func parseTag(tagStr string) Tag {
 		}
 	}
 
+	if tag.joined() != value {
+		tag.Text = value
+	}
+
 	return tag
 }
 
 func parseType(typ reflect.Type) string {
 	switch typ.Kind() {
 	case reflect.Ptr:
-		return "*" + typ.Elem().String()
+		return "*" + elemType(typ.Elem())
 	case reflect.Slice:
-		return "[]" + typ.Elem().String()
+		return "[]" + elemType(typ.Elem())
+	case reflect.Array:
+		return "[" + strconv.Itoa(typ.Len()) + "]" + elemType(typ.Elem())
 	case reflect.Struct:
 		if len(typ.PkgPath()) > 0 {
 			return typ.PkgPath() + "." + typ.Name()
@@ -53,7 +73,7 @@ func parseType(typ reflect.Type) string {
 
 		return typ.Kind().String()
 	case reflect.Map:
-		return "map[" + typ.Key().String() + "]" + typ.Elem().String()
+		return "map[" + elemType(typ.Key()) + "]" + elemType(typ.Elem())
 	case reflect.Func:
 		var args, results string
 
@@ -84,3 +104,81 @@ func parseType(typ reflect.Type) string {
 		return typ.Kind().String()
 	}
 }
+
+// elemType returns a type of an element of a pointer, slice, array or map.
+// Like parseType, it reduces named basic types, like time.Duration, to
+// their kinds, so only structs and interfaces keep their names.
+func elemType(typ reflect.Type) string {
+	switch typ.Kind() {
+	case reflect.Ptr:
+		return "*" + elemType(typ.Elem())
+	case reflect.Slice:
+		return "[]" + elemType(typ.Elem())
+	case reflect.Array:
+		return "[" + strconv.Itoa(typ.Len()) + "]" + elemType(typ.Elem())
+	case reflect.Map:
+		return "map[" + elemType(typ.Key()) + "]" + elemType(typ.Elem())
+	case reflect.Struct, reflect.Interface, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return typ.String()
+	default:
+		return typ.Kind().String()
+	}
+}
+
+// funcSignature returns a signature of a function type like
+// "func(int, ...string) (bool, error)", skipping the first skip arguments.
+func funcSignature(typ reflect.Type, skip int) string {
+	args := make([]string, 0, typ.NumIn())
+	for i := skip; i < typ.NumIn(); i++ {
+		if typ.IsVariadic() && i == typ.NumIn()-1 {
+			args = append(args, "..."+typ.In(i).Elem().String())
+			continue
+		}
+
+		args = append(args, typ.In(i).String())
+	}
+
+	results := make([]string, 0, typ.NumOut())
+	for i := 0; i < typ.NumOut(); i++ {
+		results = append(results, typ.Out(i).String())
+	}
+
+	s := "func(" + strings.Join(args, ", ") + ")"
+
+	switch len(results) {
+	case 0:
+		return s
+	case 1:
+		return s + " " + results[0]
+	default:
+		return s + " (" + strings.Join(results, ", ") + ")"
+	}
+}
+
+// splitTypeArgs splits a name of a generic type instance, like
+// "Pair[int,map[string]int]", into the name and type arguments.
+func splitTypeArgs(name string) (string, []string) {
+	open := strings.IndexByte(name, '[')
+	if open < 0 || !strings.HasSuffix(name, "]") {
+		return name, nil
+	}
+
+	args := make([]string, 0)
+	depth, start := 0, open+1
+
+	for i := start; i < len(name)-1; i++ {
+		switch name[i] {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, name[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return name[:open], append(args, name[start:len(name)-1])
+}
@@ -27,6 +27,33 @@ func TestParseTags(t *testing.T) {
 				{Tag: "sql", Value: "-"},
 			},
 		},
+		{
+			name: "Test with colons in value",
+			tag:  `gorm:"column:name;type:varchar(100)"`,
+			want: []Tag{{Tag: "gorm", Value: "column:name;type:varchar(100)"}},
+		},
+		{
+			name: "Test with escaped quotes",
+			tag:  `default:"say \"hi\"" sql:"x"`,
+			want: []Tag{{Tag: "default", Value: `say "hi"`}, {Tag: "sql", Value: "x"}},
+		},
+		{
+			name: "Test with spaces around commas",
+			tag:  `desc:"Port, in range 1-65535" description:" a, b "`,
+			want: []Tag{
+				{Tag: "desc", Value: "Port", Optional: []string{"in range 1-65535"}, Text: "Port, in range 1-65535"},
+				{Tag: "description", Value: "a", Optional: []string{"b"}, Text: " a, b "},
+			},
+		},
+		{
+			name: "Test with profile tags",
+			tag:  `json:"name" json.admin:"secret" validate.create:"required"`,
+			want: []Tag{
+				{Tag: "json", Value: "name"},
+				{Tag: "json.admin", Value: "secret"},
+				{Tag: "validate.create", Value: "required"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -68,6 +95,11 @@ func TestParseType(t *testing.T) {
 			typ:  reflect.TypeOf([]*[]*string{}),
 			want: "[]*[]*string",
 		},
+		{
+			name: "Array type",
+			typ:  reflect.TypeOf([2]*string{}),
+			want: "[2]*string",
+		},
 		{
 			name: "Map type",
 			typ:  reflect.TypeOf(map[string]int{}),
@@ -107,3 +139,24 @@ func TestParseType(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitTypeArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantName string
+		wantArgs []string
+	}{
+		{"User", "User", nil},
+		{"Box[int]", "Box", []string{"int"}},
+		{"Pair[int,map[string]int]", "Pair", []string{"int", "map[string]int"}},
+		{"Fn[func(int, string) error,example.com/x.T]", "Fn", []string{"func(int, string) error", "example.com/x.T"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, args := splitTypeArgs(tt.name)
+			if name != tt.wantName || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("splitTypeArgs() = %q, %v, want %q, %v", name, args, tt.wantName, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package textra
+
+import (
+	"sort"
+	"strings"
+)
+
+// Profile returns the struct as seen by the profile. Tags may have
+// per-profile names, like `json.admin:"secret"` or
+// `validate.create:"required"`, plain names belong to the default profile.
+// For every tag name the profile-specific tag is used if it's present, else
+// the plain one. Profile-specific tags are renamed to plain names, tags of
+// other profiles are removed. Profile("") removes all profile-specific tags.
+func (s Struct) Profile(name string) Struct {
+	profiled := make(Struct, 0, len(s))
+	for _, field := range s {
+		field.Tags = field.Tags.Profile(name)
+		profiled = append(profiled, field)
+	}
+
+	return profiled
+}
+
+// Profiles returns sorted names of all profiles used in the struct.
+func (s Struct) Profiles() []string {
+	unique := make(map[string]bool)
+	for _, field := range s {
+		for _, tag := range field.Tags {
+			if _, profile := splitProfile(tag.Tag); profile != "" {
+				unique[profile] = true
+			}
+		}
+	}
+
+	profiles := make([]string, 0, len(unique))
+	for profile := range unique {
+		profiles = append(profiles, profile)
+	}
+
+	sort.Strings(profiles)
+
+	return profiles
+}
+
+// Profile returns tags as seen by the profile. See Struct.Profile.
+func (t Tags) Profile(name string) Tags {
+	overridden := make(map[string]bool)
+
+	if name != "" {
+		for _, tag := range t {
+			if base, profile := splitProfile(tag.Tag); profile == name {
+				overridden[base] = true
+			}
+		}
+	}
+
+	profiled := make(Tags, 0, len(t))
+	seen := make(map[string]bool)
+
+	for _, tag := range t {
+		base, profile := splitProfile(tag.Tag)
+		plain := profile == "" && !overridden[base]
+		if (profile != name && !plain) || seen[base] {
+			continue
+		}
+
+		seen[base] = true
+		tag.Tag = base
+		profiled = append(profiled, tag)
+	}
+
+	return profiled
+}
+
+// splitProfile splits a tag name like "json.admin" into a base name and a
+// profile.
+func splitProfile(tag string) (string, string) {
+	i := strings.IndexByte(tag, '.')
+	if i < 0 {
+		return tag, ""
+	}
+
+	return tag[:i], tag[i+1:]
+}
@@ -0,0 +1,69 @@
+package textra_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ravsii/textra"
+)
+
+type profileStruct struct {
+	ID       int    `json:"id" validate.update:"required"`
+	Name     string `json:"name" json.admin:"full_name" validate:"omitempty" validate.create:"required"`
+	Password string `json:"-" json.admin:"password,omitempty"`
+	Note     string `json.public:"note"`
+}
+
+func TestStruct_Profile(t *testing.T) {
+	s := textra.Extract((*profileStruct)(nil))
+
+	tests := []struct {
+		profile string
+		want    textra.Struct
+	}{
+		{"", textra.Struct{
+			{Name: "ID", Type: "int", Tags: textra.Tags{{Tag: "json", Value: "id"}}},
+			{Name: "Name", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "name"}, {Tag: "validate", Value: "omitempty"}}},
+			{Name: "Password", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "-"}}},
+			{Name: "Note", Type: "string", Tags: textra.Tags{}},
+		}},
+		{"admin", textra.Struct{
+			{Name: "ID", Type: "int", Tags: textra.Tags{{Tag: "json", Value: "id"}}},
+			{Name: "Name", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "full_name"}, {Tag: "validate", Value: "omitempty"}}},
+			{Name: "Password", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "password", Optional: []string{"omitempty"}}}},
+			{Name: "Note", Type: "string", Tags: textra.Tags{}},
+		}},
+		{"create", textra.Struct{
+			{Name: "ID", Type: "int", Tags: textra.Tags{{Tag: "json", Value: "id"}}},
+			{Name: "Name", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "name"}, {Tag: "validate", Value: "required"}}},
+			{Name: "Password", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "-"}}},
+			{Name: "Note", Type: "string", Tags: textra.Tags{}},
+		}},
+		{"public", textra.Struct{
+			{Name: "ID", Type: "int", Tags: textra.Tags{{Tag: "json", Value: "id"}}},
+			{Name: "Name", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "name"}, {Tag: "validate", Value: "omitempty"}}},
+			{Name: "Password", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "-"}}},
+			{Name: "Note", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "note"}}},
+		}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.profile, func(t *testing.T) {
+			if got := s.Profile(tt.profile); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Profile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := textra.Extract((*profileStruct)(nil)); !reflect.DeepEqual(got, s) {
+		t.Errorf("Profile() modified the struct: %v", s)
+	}
+}
+
+func TestStruct_Profiles(t *testing.T) {
+	want := []string{"admin", "create", "public", "update"}
+	if got := textra.Extract((*profileStruct)(nil)).Profiles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Profiles() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+package textra
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ProtobufTag is a parsed protobuf tag of a protoc-gen-go generated struct,
+// like
+//
+//	`protobuf:"bytes,1,opt,name=user_id,json=userId,proto3"`.
+//
+// It's returned by Tag.Parse for protobuf, protobuf_key and protobuf_val tags.
+type ProtobufTag struct {
+	// WireType is an encoding, like "varint", "zigzag64", "fixed32" or "bytes".
+	WireType string `json:"wireType"`
+	Number   int    `json:"number"`
+	// Cardinality is one of "opt", "req" or "rep".
+	Cardinality string `json:"cardinality"`
+	// Name is a field's name in the .proto file.
+	Name     string `json:"name,omitempty"`
+	JSONName string `json:"jsonName,omitempty"`
+	// Enum is a fully-qualified enum name, like "pkg.Status", if the field
+	// is an enum.
+	Enum   string `json:"enum,omitempty"`
+	Proto3 bool   `json:"proto3,omitempty"`
+	Packed bool   `json:"packed,omitempty"`
+	// Oneof is true if the field is a member of a oneof. Such fields belong
+	// to oneof wrapper structs.
+	Oneof bool `json:"oneof,omitempty"`
+	// Default holds a default value of a proto2 field.
+	// Use HasDefault to tell an empty default from a missing one.
+	Default    string `json:"default,omitempty"`
+	HasDefault bool   `json:"hasDefault,omitempty"`
+	Weak       string `json:"weak,omitempty"`
+}
+
+// ProtobufField is a field of a generated protobuf message.
+type ProtobufField struct {
+	// Field is a struct field's name.
+	Field string `json:"field"`
+	// Tag is zero for oneof fields, which have only a protobuf_oneof tag.
+	Tag ProtobufTag `json:"tag"`
+	// Key and Value are set for map fields.
+	Key   *ProtobufTag `json:"key,omitempty"`
+	Value *ProtobufTag `json:"value,omitempty"`
+	// Oneof is a oneof's name, taken from a protobuf_oneof tag.
+	Oneof string `json:"oneof,omitempty"`
+}
+
+var protobufTagParser = TagParserFunc(func(tag Tag) (interface{}, error) {
+	return ParseProtobufTag(tag)
+})
+
+// ParseProtobufTag parses a protobuf tag.
+func ParseProtobufTag(tag Tag) (ProtobufTag, error) {
+	parts := strings.Split(tag.Raw(), ",")
+	if len(parts) < 3 {
+		return ProtobufTag{}, errors.New("textra: protobuf: expected wire type, number and cardinality")
+	}
+
+	number, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return ProtobufTag{}, fmt.Errorf("textra: protobuf: field number: %v", err)
+	}
+
+	parsed := ProtobufTag{
+		WireType:    parts[0],
+		Number:      number,
+		Cardinality: parts[2],
+	}
+
+	for i := 3; i < len(parts); i++ {
+		key, value := splitOption(parts[i])
+
+		switch key {
+		case "name":
+			parsed.Name = value
+		case "json":
+			parsed.JSONName = value
+		case "enum":
+			parsed.Enum = value
+		case "proto3":
+			parsed.Proto3 = true
+		case "packed":
+			parsed.Packed = true
+		case "oneof":
+			parsed.Oneof = true
+		case "weak":
+			parsed.Weak = value
+		case "def":
+			// Default is always the last option and may contain commas.
+			_, parsed.Default = splitOption(strings.Join(parts[i:], ","))
+			parsed.HasDefault = true
+			i = len(parts)
+		}
+	}
+
+	return parsed, nil
+}
+
+// Repeated is a shortcut for t.Cardinality == "rep".
+func (t ProtobufTag) Repeated() bool {
+	return t.Cardinality == "rep"
+}
+
+// ProtobufFields returns all fields of s which have protobuf, protobuf_key,
+// protobuf_val or protobuf_oneof tags.
+func (s Struct) ProtobufFields() ([]ProtobufField, error) {
+	fields := make([]ProtobufField, 0)
+
+	for _, field := range s {
+		pf := ProtobufField{Field: field.Name}
+		found := false
+
+		for _, tag := range field.Tags {
+			if tag.Tag == "protobuf_oneof" {
+				pf.Oneof = tag.Value
+				found = true
+
+				continue
+			}
+
+			if tag.Tag != "protobuf" && tag.Tag != "protobuf_key" && tag.Tag != "protobuf_val" {
+				continue
+			}
+
+			parsed, err := ParseProtobufTag(tag)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", field.Name, err)
+			}
+
+			switch tag.Tag {
+			case "protobuf":
+				pf.Tag = parsed
+			case "protobuf_key":
+				pf.Key = &parsed
+			case "protobuf_val":
+				pf.Value = &parsed
+			}
+
+			found = true
+		}
+
+		if found {
+			fields = append(fields, pf)
+		}
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,81 @@
+package textra_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ravsii/textra"
+)
+
+func TestParseProtobufTag(t *testing.T) {
+	testCases := []struct {
+		name    string
+		raw     string
+		want    textra.ProtobufTag
+		wantErr bool
+	}{
+		{"proto3 string", "bytes,1,opt,name=user_id,json=userId,proto3", textra.ProtobufTag{
+			WireType: "bytes", Number: 1, Cardinality: "opt", Name: "user_id", JSONName: "userId", Proto3: true,
+		}, false},
+		{"enum", "varint,2,opt,name=status,proto3,enum=users.Status", textra.ProtobufTag{
+			WireType: "varint", Number: 2, Cardinality: "opt", Name: "status", Proto3: true, Enum: "users.Status",
+		}, false},
+		{"repeated packed", "varint,3,rep,packed,name=ids", textra.ProtobufTag{
+			WireType: "varint", Number: 3, Cardinality: "rep", Packed: true, Name: "ids",
+		}, false},
+		{"default with comma", "bytes,4,opt,name=greeting,def=hello, world", textra.ProtobufTag{
+			WireType: "bytes", Number: 4, Cardinality: "opt", Name: "greeting", Default: "hello, world", HasDefault: true,
+		}, false},
+		{"too short", "bytes,1", textra.ProtobufTag{}, true},
+		{"bad number", "bytes,x,opt", textra.ProtobufTag{}, true},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		got, err := textra.ParseProtobufTag(textra.Tag{Tag: "protobuf", Value: testCase.raw})
+		if (err != nil) != testCase.wantErr {
+			t.Errorf("%s: err %v, wantErr %t", testCase.name, err, testCase.wantErr)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, testCase.want) {
+			t.Errorf("%s: got %+v want %+v", testCase.name, got, testCase.want)
+		}
+	}
+
+	if !(textra.ProtobufTag{Cardinality: "rep"}).Repeated() {
+		t.Errorf("Repeated() = false, want true")
+	}
+}
+
+func TestProtobufFields(t *testing.T) {
+	type User struct {
+		state   struct{}
+		UserId  string            `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` //nolint: stylecheck
+		Labels  map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+		Contact interface{}       `protobuf_oneof:"contact"`
+	}
+
+	got, err := textra.Extract((*User)(nil)).ProtobufFields()
+	if err != nil {
+		t.Fatalf("ProtobufFields() error = %v", err)
+	}
+
+	want := []textra.ProtobufField{
+		{Field: "UserId", Tag: textra.ProtobufTag{
+			WireType: "bytes", Number: 1, Cardinality: "opt", Name: "user_id", JSONName: "userId", Proto3: true,
+		}},
+		{
+			Field: "Labels",
+			Tag:   textra.ProtobufTag{WireType: "bytes", Number: 2, Cardinality: "rep", Name: "labels", Proto3: true},
+			Key:   &textra.ProtobufTag{WireType: "bytes", Number: 1, Cardinality: "opt", Name: "key", Proto3: true},
+			Value: &textra.ProtobufTag{WireType: "bytes", Number: 2, Cardinality: "opt", Name: "value", Proto3: true},
+		},
+		{Field: "Contact", Oneof: "contact"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ProtobufFields() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,203 @@
+// Package protogen generates proto3 message definitions from textra Structs.
+//
+// Only fields with a proto tag are exported. The tag's value is a stable
+// field number, options may override a field's name and type:
+//
+//	`proto:"3"`
+//	`proto:"4,name=user_id,type=sint64"`
+//
+// Names default to snake_cased field names.
+package protogen
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ravsii/textra"
+	"github.com/ravsii/textra/internal/naming"
+	"github.com/ravsii/textra/internal/typestr"
+)
+
+// maxFieldNumber is the largest field number protobuf allows.
+const maxFieldNumber = 1<<29 - 1
+
+// scalars maps Go types to protobuf scalar types.
+var scalars = map[string]string{
+	"bool":      "bool",
+	"int":       "int64",
+	"int8":      "int32",
+	"int16":     "int32",
+	"int32":     "int32",
+	"int64":     "int64",
+	"uint":      "uint64",
+	"uint8":     "uint32",
+	"uint16":    "uint32",
+	"uint32":    "uint32",
+	"uint64":    "uint64",
+	"float32":   "float",
+	"float64":   "double",
+	"string":    "string",
+	"time.Time": "google.protobuf.Timestamp",
+}
+
+// wellKnownImports maps well-known types to files which define them.
+var wellKnownImports = map[string]string{
+	"google.protobuf.Timestamp": "google/protobuf/timestamp.proto",
+}
+
+// Message is a Struct with a name, which becomes a message.
+type Message struct {
+	Name   string
+	Fields textra.Struct
+}
+
+// Generate returns a .proto file with the given package and messages.
+// Fields of named struct types reference messages by their names, without
+// packages.
+func Generate(pkg string, messages ...Message) (string, error) {
+	imports := make(map[string]bool)
+	bodies := make([]string, 0, len(messages))
+
+	for _, msg := range messages {
+		body, err := message(msg, imports)
+		if err != nil {
+			return "", err
+		}
+
+		bodies = append(bodies, body)
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("syntax = \"proto3\";\n\n")
+
+	if pkg != "" {
+		sb.WriteString("package " + pkg + ";\n\n")
+	}
+
+	if len(imports) > 0 {
+		for _, file := range sortedKeys(imports) {
+			sb.WriteString("import \"" + file + "\";\n")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(strings.Join(bodies, "\n"))
+
+	return sb.String(), nil
+}
+
+func message(msg Message, imports map[string]bool) (string, error) {
+	lines := make([]string, 0, len(msg.Fields))
+	numbers := make(map[int]string)
+
+	for _, field := range msg.Fields {
+		tag, ok := field.Tags.ByName("proto")
+		if !ok || tag.Ignored() {
+			continue
+		}
+
+		number, err := strconv.Atoi(tag.Value)
+		if err != nil || number < 1 || number > maxFieldNumber || (number >= 19000 && number <= 19999) {
+			return "", fmt.Errorf("protogen: %s.%s: invalid field number %q", msg.Name, field.Name, tag.Value)
+		}
+
+		if other, ok := numbers[number]; ok {
+			return "", fmt.Errorf("protogen: %s: fields %s and %s share number %d", msg.Name, other, field.Name, number)
+		}
+
+		numbers[number] = field.Name
+
+		name := naming.Snake(field.Name)
+		if v, ok := tag.Option("name"); ok {
+			name = v
+		}
+
+		typ, ok := tag.Option("type")
+		if !ok {
+			typ, err = protoType(typestr.Parse(field.Type), true)
+			if err != nil {
+				return "", fmt.Errorf("protogen: %s.%s: %v", msg.Name, field.Name, err)
+			}
+		}
+
+		for wk, file := range wellKnownImports {
+			if strings.Contains(typ, wk) {
+				imports[file] = true
+			}
+		}
+
+		lines = append(lines, fmt.Sprintf("  %s %s = %d;", typ, name, number))
+	}
+
+	return "message " + msg.Name + " {\n" + strings.Join(lines, "\n") + "\n}\n", nil
+}
+
+// protoType returns a field's type with a label, if any. Labels are allowed
+// only at the top level, since protobuf can't nest repeated fields.
+func protoType(t *typestr.Type, top bool) (string, error) {
+	base, isPtr := t.Deref()
+
+	switch base.Kind {
+	case typestr.Slice:
+		if !top {
+			return "", fmt.Errorf("nested repeated fields are not supported")
+		}
+
+		if elem, _ := base.Elem.Deref(); elem.Kind == typestr.Named && elem.Name == "uint8" {
+			return "bytes", nil
+		}
+
+		elem, err := protoType(base.Elem, false)
+		if err != nil {
+			return "", err
+		}
+
+		return "repeated " + elem, nil
+	case typestr.Map:
+		if !top {
+			return "", fmt.Errorf("nested maps are not supported")
+		}
+
+		key, err := protoType(base.Key, false)
+		if err != nil {
+			return "", err
+		}
+
+		value, err := protoType(base.Elem, false)
+		if err != nil {
+			return "", err
+		}
+
+		return "map<" + key + ", " + value + ">", nil
+	}
+
+	if scalar, ok := scalars[base.Name]; ok {
+		// Pointers to scalars keep track of presence.
+		if isPtr && top && !strings.HasPrefix(scalar, "google.") {
+			return "optional " + scalar, nil
+		}
+
+		return scalar, nil
+	}
+
+	if base.Qualified() {
+		return base.BaseName(), nil
+	}
+
+	return "", fmt.Errorf("unsupported type %s, use the type option", base.Name)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
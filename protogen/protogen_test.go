@@ -0,0 +1,116 @@
+package protogen_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ravsii/textra"
+	"github.com/ravsii/textra/protogen"
+)
+
+type Address struct {
+	City string `proto:"1"`
+}
+
+// Status is a named non-struct type, which is a scalar in protobuf.
+type Status int32
+
+type User struct {
+	ID        int64             `proto:"1"`
+	Name      string            `proto:"2,name=full_name"`
+	Nick      *string           `proto:"3"`
+	Tags      []string          `proto:"4"`
+	Labels    map[string]int32  `proto:"5"`
+	Home      *Address          `proto:"6"`
+	Addresses []Address         `proto:"7"`
+	Avatar    []byte            `proto:"8"`
+	CreatedAt time.Time         `proto:"9"`
+	Balance   int64             `proto:"10,type=sint64"`
+	State     Status            `proto:"11"`
+	States    []Status          `proto:"12"`
+	Timeouts  []time.Duration   `proto:"13"`
+	Internal  string            `proto:"-"`
+	NoTag     map[string]string `json:"no_tag"`
+}
+
+func TestGenerate(t *testing.T) {
+	got, err := protogen.Generate("users.v1",
+		protogen.Message{Name: "User", Fields: textra.Extract((*User)(nil))},
+		protogen.Message{Name: "Address", Fields: textra.Extract((*Address)(nil))},
+	)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := `syntax = "proto3";
+
+package users.v1;
+
+import "google/protobuf/timestamp.proto";
+
+message User {
+  int64 id = 1;
+  string full_name = 2;
+  optional string nick = 3;
+  repeated string tags = 4;
+  map<string, int32> labels = 5;
+  Address home = 6;
+  repeated Address addresses = 7;
+  bytes avatar = 8;
+  google.protobuf.Timestamp created_at = 9;
+  sint64 balance = 10;
+  int32 state = 11;
+  repeated int32 states = 12;
+  repeated int64 timeouts = 13;
+}
+
+message Address {
+  string city = 1;
+}
+`
+
+	if got != want {
+		t.Errorf("Generate() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	type BadNumber struct {
+		ID int `proto:"x"`
+	}
+
+	type Reserved struct {
+		ID int `proto:"19000"`
+	}
+
+	type Duplicate struct {
+		A int `proto:"1"`
+		B int `proto:"1"`
+	}
+
+	type Nested struct {
+		Matrix [][]int `proto:"1"`
+	}
+
+	type Unsupported struct {
+		Any interface{} `proto:"1"`
+	}
+
+	tests := []struct {
+		name  string
+		input interface{}
+	}{
+		{"bad number", (*BadNumber)(nil)},
+		{"reserved number", (*Reserved)(nil)},
+		{"duplicate number", (*Duplicate)(nil)},
+		{"nested repeated", (*Nested)(nil)},
+		{"unsupported", (*Unsupported)(nil)},
+	}
+
+	for _, tt := range tests {
+		msg := protogen.Message{Name: "M", Fields: textra.Extract(tt.input)}
+		if _, err := protogen.Generate("", msg); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
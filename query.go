@@ -0,0 +1,435 @@
+package textra
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Query is a compiled filter expression. It's safe for concurrent use.
+//
+// An expression combines operands with "&&", "||", "!" and parentheses.
+// An operand is one of:
+//
+//	name, type        field's Go name and type, always present
+//	embedded          present if the field is embedded
+//	json              present if the field has a "json" tag, its value is
+//	                  the tag's value; json.value is the same
+//	json.ignored      present if the "json" tag's value is "-"
+//	json.omitempty    present if the "json" tag has "omitempty" option,
+//	                  its value is the option's value, like "3" for
+//	                  db.size in db:"name,size=3". Only options are
+//	                  checked, so json.id doesn't match json:"id"
+//	validate.rule.min present if the "validate" tag's value or one of its
+//	                  options is "min", its value is the rule's value,
+//	                  like "3" for validate:"min=3". It's meant for tags
+//	                  which are lists of rules, like validate or binding
+//
+// An operand alone is true if it's present. It can be compared with a
+// quoted string using "==", "!=", "=~" (matches regexp) and "!~" (doesn't
+// match regexp). Comparisons of missing operands are false, except "!="
+// and "!~". Strings are quoted with ' or ", a backslash escapes a quote or
+// a backslash and is kept as is before any other character, so regexps
+// don't need double escaping:
+//
+//	json && !json.ignored && (db.pk || validate.rule.required) && type =~ '^\*'
+//
+// Tags named "name", "type" or "embedded" can't be queried. Profile tags,
+// like "json.admin", can't be queried either, use Struct.Profile first.
+type Query struct {
+	expr string
+	root queryNode
+}
+
+// QueryError is returned for an invalid query expression.
+type QueryError struct {
+	Expr string
+	// Pos is a byte offset in Expr where the error occurred.
+	Pos int
+	Msg string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("textra: query %q: %s at position %d", e.Expr, e.Msg, e.Pos)
+}
+
+// CompileQuery parses a query expression. The returned Query can be reused.
+func CompileQuery(expr string) (*Query, error) {
+	p := queryParser{expr: expr}
+	p.next()
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %s", p.tok)
+	}
+
+	return &Query{expr: expr, root: root}, nil
+}
+
+// MustCompileQuery is like CompileQuery but panics if the expression can't
+// be parsed.
+func MustCompileQuery(expr string) *Query {
+	q, err := CompileQuery(expr)
+	if err != nil {
+		panic(err)
+	}
+
+	return q
+}
+
+// Match reports whether the field matches the query.
+func (q *Query) Match(f Field) bool {
+	return q.root.eval(f)
+}
+
+// Filter returns fields of s matching the query.
+func (q *Query) Filter(s Struct) Struct {
+	return s.FilterFunc(q.Match)
+}
+
+// String returns the source expression.
+func (q *Query) String() string {
+	return q.expr
+}
+
+// Query returns fields matching the query expression. See Query for the
+// syntax. Use CompileQuery to compile an expression once.
+func (s Struct) Query(expr string) (Struct, error) {
+	q, err := CompileQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.Filter(s), nil
+}
+
+type queryNode interface {
+	eval(f Field) bool
+}
+
+type (
+	orNode  struct{ left, right queryNode }
+	andNode struct{ left, right queryNode }
+	notNode struct{ node queryNode }
+	// presentNode is an operand without a comparison.
+	presentNode struct{ operand queryOperand }
+	cmpNode     struct {
+		operand queryOperand
+		op      string
+		value   string
+		re      *regexp.Regexp
+	}
+)
+
+func (n orNode) eval(f Field) bool  { return n.left.eval(f) || n.right.eval(f) }
+func (n andNode) eval(f Field) bool { return n.left.eval(f) && n.right.eval(f) }
+func (n notNode) eval(f Field) bool { return !n.node.eval(f) }
+
+func (n presentNode) eval(f Field) bool {
+	_, ok := n.operand(f)
+	return ok
+}
+
+func (n cmpNode) eval(f Field) bool {
+	v, ok := n.operand(f)
+
+	switch n.op {
+	case "==":
+		return ok && v == n.value
+	case "!=":
+		return !ok || v != n.value
+	case "=~":
+		return ok && n.re.MatchString(v)
+	default: // "!~"
+		return !ok || !n.re.MatchString(v)
+	}
+}
+
+// queryOperand returns an operand's value and whether it's present.
+type queryOperand func(f Field) (string, bool)
+
+func newOperand(path string) (queryOperand, bool) {
+	parts := strings.Split(path, ".")
+
+	switch {
+	case path == "name":
+		return func(f Field) (string, bool) { return f.Name, true }, true
+	case path == "type":
+		return func(f Field) (string, bool) { return f.Type, true }, true
+	case path == "embedded":
+		return func(f Field) (string, bool) { return "", f.Embedded }, true
+	case len(parts) == 1:
+		return tagOperand(path, func(t Tag) (string, bool) { return t.Value, true }), true
+	case len(parts) == 3 && parts[0] != "" && parts[1] == "rule" && parts[2] != "":
+		rule := parts[2]
+
+		return tagOperand(parts[0], func(t Tag) (string, bool) {
+			if name, value := splitOption(t.Value); name == rule {
+				return value, true
+			}
+
+			return t.Option(rule)
+		}), true
+	case len(parts) != 2 || parts[0] == "" || parts[1] == "":
+		return nil, false
+	}
+
+	switch key, prop := parts[0], parts[1]; prop {
+	case "value":
+		return tagOperand(key, func(t Tag) (string, bool) { return t.Value, true }), true
+	case "ignored":
+		return tagOperand(key, func(t Tag) (string, bool) { return t.Value, t.Ignored() }), true
+	default:
+		return tagOperand(key, func(t Tag) (string, bool) { return t.Option(prop) }), true
+	}
+}
+
+func tagOperand(key string, fn func(Tag) (string, bool)) queryOperand {
+	return func(f Field) (string, bool) {
+		tag, ok := f.Tags.ByName(key)
+		if !ok {
+			return "", false
+		}
+
+		return fn(tag)
+	}
+}
+
+type queryTokenKind int
+
+const (
+	tokEOF queryTokenKind = iota
+	tokIdent
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	pos  int
+}
+
+func (t queryToken) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+
+	return fmt.Sprintf("%q", t.text)
+}
+
+type queryParser struct {
+	expr string
+	pos  int
+	tok  queryToken
+	err  *QueryError
+}
+
+func (p *queryParser) errorf(format string, args ...interface{}) *QueryError {
+	if p.err != nil {
+		return p.err
+	}
+
+	return &QueryError{Expr: p.expr, Pos: p.tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// next reads the next token into p.tok. Lexing errors are stored in p.err.
+func (p *queryParser) next() {
+	for p.pos < len(p.expr) && strings.IndexByte(" \t\n\r", p.expr[p.pos]) >= 0 {
+		p.pos++
+	}
+
+	start := p.pos
+	if start >= len(p.expr) {
+		p.tok = queryToken{kind: tokEOF, pos: start}
+		return
+	}
+
+	c := p.expr[start]
+
+	switch {
+	case c == '(':
+		p.pos++
+		p.tok = queryToken{kind: tokLParen, text: "(", pos: start}
+	case c == ')':
+		p.pos++
+		p.tok = queryToken{kind: tokRParen, text: ")", pos: start}
+	case c == '\'' || c == '"':
+		p.lexString(c)
+	case isIdentByte(c):
+		for p.pos < len(p.expr) && (isIdentByte(p.expr[p.pos]) || p.expr[p.pos] == '.') {
+			p.pos++
+		}
+
+		p.tok = queryToken{kind: tokIdent, text: p.expr[start:p.pos], pos: start}
+	default:
+		for _, op := range []string{"&&", "||", "==", "!=", "=~", "!~", "!"} {
+			if strings.HasPrefix(p.expr[start:], op) {
+				p.pos += len(op)
+				p.tok = queryToken{kind: tokOp, text: op, pos: start}
+
+				return
+			}
+		}
+
+		p.tok = queryToken{kind: tokEOF, pos: start}
+		p.err = &QueryError{Expr: p.expr, Pos: start, Msg: fmt.Sprintf("unexpected character %q", c)}
+	}
+}
+
+func (p *queryParser) lexString(quote byte) {
+	start := p.pos
+
+	var sb strings.Builder
+
+	for i := start + 1; i < len(p.expr); i++ {
+		switch c := p.expr[i]; {
+		case c == quote:
+			p.pos = i + 1
+			p.tok = queryToken{kind: tokString, text: sb.String(), pos: start}
+
+			return
+		case c == '\\' && i+1 < len(p.expr) && (p.expr[i+1] == quote || p.expr[i+1] == '\\'):
+			i++
+			sb.WriteByte(p.expr[i])
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	p.pos = len(p.expr)
+	p.tok = queryToken{kind: tokEOF, pos: start}
+	p.err = &QueryError{Expr: p.expr, Pos: start, Msg: "unterminated string"}
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '-' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.tok.kind == tokOp && p.tok.text == "||" {
+		p.next()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = orNode{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.tok.kind == tokOp && p.tok.text == "&&" {
+		p.next()
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = andNode{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *queryParser) parseUnary() (queryNode, error) {
+	if p.tok.kind == tokOp && p.tok.text == "!" {
+		p.next()
+
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return notNode{node}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	switch p.tok.kind {
+	case tokLParen:
+		p.next()
+
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.tok.kind != tokRParen {
+			return nil, p.errorf("expected \")\", got %s", p.tok)
+		}
+
+		p.next()
+
+		return node, nil
+	case tokIdent:
+		return p.parseComparison()
+	default:
+		return nil, p.errorf("expected operand, got %s", p.tok)
+	}
+}
+
+func (p *queryParser) parseComparison() (queryNode, error) {
+	operand, ok := newOperand(p.tok.text)
+	if !ok {
+		return nil, p.errorf("invalid operand %s", p.tok)
+	}
+
+	p.next()
+
+	if p.tok.kind != tokOp || !isComparison(p.tok.text) {
+		return presentNode{operand}, nil
+	}
+
+	op := p.tok.text
+	p.next()
+
+	if p.tok.kind != tokString {
+		return nil, p.errorf("expected quoted string after %q, got %s", op, p.tok)
+	}
+
+	node := cmpNode{operand: operand, op: op, value: p.tok.text}
+
+	if op == "=~" || op == "!~" {
+		re, err := regexp.Compile(p.tok.text)
+		if err != nil {
+			return nil, p.errorf("invalid regexp: %v", err)
+		}
+
+		node.re = re
+	}
+
+	p.next()
+
+	return node, nil
+}
+
+func isComparison(op string) bool {
+	return op == "==" || op == "!=" || op == "=~" || op == "!~"
+}
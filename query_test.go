@@ -0,0 +1,119 @@
+package textra_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ravsii/textra"
+)
+
+type queryStruct struct {
+	ID       *int    `json:"id" db:"id,pk"`
+	Name     *string `json:"name" validate:"required,min=3"`
+	Password *string `json:"-" validate:"required"`
+	Age      int     `json:"age,omitempty" validate:"min=18"`
+	Note     string
+}
+
+func TestStruct_Query(t *testing.T) {
+	t.Parallel()
+
+	s := textra.Extract((*queryStruct)(nil))
+
+	testCases := []struct {
+		expr string
+		want []string
+	}{
+		{`json && !json.ignored && (db.pk || validate.rule.required) && type =~ '^\*'`, []string{"ID", "Name"}},
+		{`json`, []string{"ID", "Name", "Password", "Age"}},
+		{`!json`, []string{"Note"}},
+		{`json.ignored`, []string{"Password"}},
+		{`json == "age"`, []string{"Age"}},
+		{`json.value != 'age'`, []string{"ID", "Name", "Password", "Note"}},
+		{`validate.rule.min == '3' || validate.rule.min == "18"`, []string{"Name", "Age"}},
+		{`validate.min`, []string{"Name"}},
+		{`validate.required || json.id || json.omitempty == ''`, []string{"Age"}},
+		{`json.omitempty`, []string{"Age"}},
+		{`name =~ '^(ID|Note)$'`, []string{"ID", "Note"}},
+		{`type !~ '^\*'`, []string{"Age", "Note"}},
+		{`!!embedded || name == 'it\'s'`, []string{}},
+		{`db || validate && json.omitempty`, []string{"ID", "Age"}},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.expr, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := s.Query(testCase.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			names := make([]string, 0, len(got))
+			for _, f := range got {
+				names = append(names, f.Name)
+			}
+
+			if !reflect.DeepEqual(names, testCase.want) {
+				t.Errorf("Query() = %v, want %v", names, testCase.want)
+			}
+		})
+	}
+}
+
+func TestCompileQuery_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expr    string
+		wantPos int
+		wantMsg string
+	}{
+		{``, 0, "expected operand, got end of expression"},
+		{`json &&`, 7, "expected operand"},
+		{`(json`, 5, `expected ")"`},
+		{`json db`, 5, `unexpected "db"`},
+		{`json == db`, 8, "expected quoted string"},
+		{`json @`, 5, "unexpected character"},
+		{`name == 'x`, 8, "unterminated string"},
+		{`type =~ '('`, 8, "invalid regexp"},
+		{`a.b.c`, 0, "invalid operand"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.expr, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := textra.CompileQuery(testCase.expr)
+
+			qerr, ok := err.(*textra.QueryError)
+			if !ok {
+				t.Fatalf("CompileQuery() error = %v, want *QueryError", err)
+			}
+
+			if qerr.Pos != testCase.wantPos || !strings.Contains(qerr.Msg, testCase.wantMsg) {
+				t.Errorf("CompileQuery() error = %v, want %q at %d", err, testCase.wantMsg, testCase.wantPos)
+			}
+		})
+	}
+}
+
+func TestMustCompileQuery(t *testing.T) {
+	t.Parallel()
+
+	q := textra.MustCompileQuery(`db.pk`)
+	if got := q.Filter(textra.Extract((*queryStruct)(nil))); len(got) != 1 || got[0].Name != "ID" {
+		t.Errorf("Filter() = %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustCompileQuery() didn't panic")
+		}
+	}()
+
+	textra.MustCompileQuery(`(`)
+}
@@ -0,0 +1,241 @@
+package textra
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExtractDir parses Go files of a single package in dir and returns all its
+// top-level struct types by their names. Test files and files excluded by
+// build constraints for the current platform are skipped.
+//
+// Unlike Extract, it doesn't need the package to be compiled in, but field
+// types are returned as they are written in the source, like "byte" instead
+// of "uint8". Anonymous structs and empty interfaces are reported as
+// "struct" and "interface", like Extract does. Fields also get their Doc and
+// Comment.
+func ExtractDir(dir string) (map[string]Struct, error) {
+	infos, err := ExtractDirInfo(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	structs := make(map[string]Struct, len(infos))
+	for name, info := range infos {
+		structs[name] = info.Fields
+	}
+
+	return structs, nil
+}
+
+// ExtractDirInfo is like ExtractDir, but also returns struct metadata, like
+// ExtractInfo does. Only Name, Meta and Fields of StructInfo are set.
+func ExtractDirInfo(dir string) (map[string]StructInfo, error) {
+	fset := token.NewFileSet()
+
+	files, err := parseDir(fset, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	structs := make(map[string]StructInfo)
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+
+				info, err := structFromAST(fset, st)
+				if err != nil {
+					return nil, fmt.Errorf("textra: %s: %v", ts.Name.Name, err)
+				}
+
+				info.Name = ts.Name.Name
+				structs[ts.Name.Name] = info
+			}
+		}
+	}
+
+	return structs, nil
+}
+
+// parseDir parses Go files of a package in dir in order of their names,
+// skipping test files and files excluded by build constraints, like
+// "//go:build ignore" or "_windows.go" on other systems.
+func parseDir(fset *token.FileSet, dir string) ([]*ast.File, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*ast.File, 0, len(entries))
+	pkgs := make(map[string]bool)
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		match, err := build.Default.MatchFile(dir, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !match {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		pkgs[file.Name.Name] = true
+		files = append(files, file)
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("textra: no Go files in %s", dir)
+	}
+
+	if len(pkgs) > 1 {
+		names := make([]string, 0, len(pkgs))
+		for name := range pkgs {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return nil, fmt.Errorf("textra: multiple packages in %s: %s", dir, strings.Join(names, ", "))
+	}
+
+	return files, nil
+}
+
+func structFromAST(fset *token.FileSet, st *ast.StructType) (StructInfo, error) {
+	info := StructInfo{
+		Meta:   make(Tags, 0),
+		Fields: make(Struct, 0, len(st.Fields.List)),
+	}
+
+	for _, f := range st.Fields.List {
+		typ, err := typeFromAST(fset, f.Type)
+		if err != nil {
+			return StructInfo{}, err
+		}
+
+		tags := make(Tags, 0)
+
+		if f.Tag != nil {
+			tag, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return StructInfo{}, err
+			}
+
+			tags = parseTags(reflect.StructTag(tag))
+		}
+
+		doc := strings.TrimSpace(f.Doc.Text())
+		comment := strings.TrimSpace(f.Comment.Text())
+
+		if len(f.Names) == 0 {
+			info.Fields = append(info.Fields, Field{
+				Name:     embeddedName(f.Type),
+				Type:     typ,
+				Tags:     tags,
+				Embedded: true,
+				Doc:      doc,
+				Comment:  comment,
+			})
+
+			continue
+		}
+
+		for _, name := range f.Names {
+			if name.Name == blankField {
+				info.Meta = append(info.Meta, tags...)
+				continue
+			}
+
+			info.Fields = append(info.Fields, Field{
+				Name:    name.Name,
+				Type:    typ,
+				Tags:    tags,
+				Doc:     doc,
+				Comment: comment,
+			})
+		}
+	}
+
+	return info, nil
+}
+
+// typeFromAST renders a type expression the way it's written in the source.
+func typeFromAST(fset *token.FileSet, expr ast.Expr) (string, error) {
+	switch t := expr.(type) {
+	case *ast.StructType:
+		return reflect.Struct.String(), nil
+	case *ast.InterfaceType:
+		if t.Methods == nil || len(t.Methods.List) == 0 {
+			return reflect.Interface.String(), nil
+		}
+	case *ast.Ident:
+		if t.Name == "any" {
+			return reflect.Interface.String(), nil
+		}
+	case *ast.StarExpr:
+		elem, err := typeFromAST(fset, t.X)
+		return "*" + elem, err
+	}
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, expr); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// embeddedName returns a name of an embedded field, which is its type's name
+// without a package and type arguments.
+func embeddedName(expr ast.Expr) string {
+	for {
+		switch t := expr.(type) {
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.SelectorExpr:
+			return t.Sel.Name
+		case *ast.Ident:
+			return t.Name
+		case *ast.IndexExpr:
+			expr = t.X
+		default:
+			return ""
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package textra_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ravsii/textra"
+)
+
+func TestExtractDir(t *testing.T) {
+	structs, err := textra.ExtractDir("testdata/source")
+	if err != nil {
+		t.Fatalf("ExtractDir() error = %v", err)
+	}
+
+	want := map[string]textra.Struct{
+		"Base": {
+			{Name: "ID", Type: "int", Tags: textra.Tags{
+				{Tag: "json", Value: "id"},
+				{Tag: "db", Value: "id", Optional: []string{"pk"}},
+			}, Doc: "ID is a primary key.\n\nIt's never reused."},
+		},
+		"User": {
+			{Name: "Base", Type: "*Base", Tags: textra.Tags{}, Embedded: true, Comment: "Base is shared by all models."},
+			{Name: "Name", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "name"}}, Comment: "display names"},
+			{Name: "Nick", Type: "string", Tags: textra.Tags{{Tag: "json", Value: "name"}}, Comment: "display names"},
+			{Name: "Born", Type: "time.Time", Tags: textra.Tags{{Tag: "json", Value: "born"}}},
+			{Name: "Meta", Type: "struct", Tags: textra.Tags{{Tag: "json", Value: "meta"}}},
+			{Name: "Any", Type: "interface", Tags: textra.Tags{{Tag: "json", Value: "any"}}},
+			{Name: "Labels", Type: "map[string][]byte", Tags: textra.Tags{{Tag: "json", Value: "labels", Optional: []string{"omitempty"}}}},
+			{Name: "Ptr", Type: "*string", Tags: textra.Tags{}},
+		},
+	}
+
+	if !reflect.DeepEqual(structs, want) {
+		t.Errorf("ExtractDir() = %v, want %v", structs, want)
+	}
+
+	if _, err := textra.ExtractDir("testdata/nonexistent"); err == nil {
+		t.Errorf("ExtractDir() should fail for missing directories")
+	}
+}
+
+func TestExtractDirInfo(t *testing.T) {
+	infos, err := textra.ExtractDirInfo("testdata/source")
+	if err != nil {
+		t.Fatalf("ExtractDirInfo() error = %v", err)
+	}
+
+	user := infos["User"]
+	if user.Name != "User" {
+		t.Errorf("Name = %q, want %q", user.Name, "User")
+	}
+
+	if table, ok := user.Table(); !ok || table != "users" {
+		t.Errorf("Table() = %q, %t, want %q, true", table, ok, "users")
+	}
+
+	if len(infos["Base"].Meta) != 0 {
+		t.Errorf("Base.Meta = %v, want none", infos["Base"].Meta)
+	}
+}
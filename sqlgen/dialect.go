@@ -0,0 +1,180 @@
+package sqlgen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ravsii/textra"
+)
+
+// Dialect is an SQL dialect of generated statements.
+type Dialect int
+
+// Supported dialects.
+const (
+	Postgres Dialect = iota
+	MySQL
+	SQLite
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case Postgres:
+		return "postgres"
+	case MySQL:
+		return "mysql"
+	case SQLite:
+		return "sqlite"
+	default:
+		return fmt.Sprintf("Dialect(%d)", int(d))
+	}
+}
+
+// types maps Go types (as textra reports them) to SQL types of each dialect,
+// which are Postgres, MySQL and SQLite, in that order.
+var types = map[string][3]string{
+	"bool":      {"BOOLEAN", "BOOLEAN", "INTEGER"},
+	"int":       {"BIGINT", "BIGINT", "INTEGER"},
+	"int8":      {"SMALLINT", "TINYINT", "INTEGER"},
+	"int16":     {"SMALLINT", "SMALLINT", "INTEGER"},
+	"int32":     {"INTEGER", "INT", "INTEGER"},
+	"int64":     {"BIGINT", "BIGINT", "INTEGER"},
+	"uint":      {"BIGINT", "BIGINT UNSIGNED", "INTEGER"},
+	"uint8":     {"SMALLINT", "TINYINT UNSIGNED", "INTEGER"},
+	"uint16":    {"INTEGER", "SMALLINT UNSIGNED", "INTEGER"},
+	"uint32":    {"BIGINT", "INT UNSIGNED", "INTEGER"},
+	"uint64":    {"NUMERIC(20)", "BIGINT UNSIGNED", "INTEGER"},
+	"float32":   {"REAL", "FLOAT", "REAL"},
+	"float64":   {"DOUBLE PRECISION", "DOUBLE", "REAL"},
+	"string":    {"TEXT", "VARCHAR(255)", "TEXT"},
+	"[]uint8":   {"BYTEA", "BLOB", "BLOB"},
+	"time.Time": {"TIMESTAMPTZ", "DATETIME", "DATETIME"},
+}
+
+// SQLType returns an SQL type of c: either the type option or a type mapped
+// from c.GoType.
+func (d Dialect) SQLType(c Column) (string, error) {
+	if c.Type != "" {
+		return c.Type, nil
+	}
+
+	if d < Postgres || d > SQLite {
+		return "", fmt.Errorf("sqlgen: unknown dialect %s", d)
+	}
+
+	mapped, ok := types[c.GoType]
+	if !ok {
+		return "", fmt.Errorf("sqlgen: %s: no %s type for %s, use the type option", c.Field, d, c.GoType)
+	}
+
+	return mapped[d], nil
+}
+
+// Quote quotes an identifier.
+func (d Dialect) Quote(ident string) string {
+	if d == MySQL {
+		return "`" + strings.Replace(ident, "`", "``", -1) + "`"
+	}
+
+	return `"` + strings.Replace(ident, `"`, `""`, -1) + `"`
+}
+
+// CreateTable is a shortcut for NewTable followed by Table.SQL.
+func CreateTable(d Dialect, name string, s textra.Struct) (string, error) {
+	table, err := NewTable(name, s)
+	if err != nil {
+		return "", err
+	}
+
+	return table.SQL(d)
+}
+
+// SQL returns a CREATE TABLE statement, followed by CREATE INDEX statements,
+// each ending with a semicolon and a new line.
+func (t Table) SQL(d Dialect) (string, error) {
+	pk := t.PrimaryKey()
+	// SQLite supports auto-increment only for an inline INTEGER PRIMARY KEY.
+	inlinePK := d == SQLite && len(pk) == 1
+
+	lines := make([]string, 0, len(t.Columns)+1)
+
+	for _, c := range t.Columns {
+		line, err := d.columnSQL(c, inlinePK)
+		if err != nil {
+			return "", err
+		}
+
+		lines = append(lines, "  "+line)
+	}
+
+	if len(pk) > 0 && !inlinePK {
+		lines = append(lines, "  PRIMARY KEY ("+d.quoteAll(pk)+")")
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("CREATE TABLE " + d.Quote(t.Name) + " (\n")
+	sb.WriteString(strings.Join(lines, ",\n"))
+	sb.WriteString("\n);\n")
+
+	for _, index := range t.Indexes {
+		sb.WriteString("CREATE ")
+
+		if index.Unique {
+			sb.WriteString("UNIQUE ")
+		}
+
+		sb.WriteString("INDEX " + d.Quote(index.Name) + " ON " + d.Quote(t.Name) +
+			" (" + d.quoteAll(index.Columns) + ");\n")
+	}
+
+	return sb.String(), nil
+}
+
+func (d Dialect) columnSQL(c Column, inlinePK bool) (string, error) {
+	typ, err := d.SQLType(c)
+	if err != nil {
+		return "", err
+	}
+
+	parts := []string{d.Quote(c.Name)}
+
+	switch {
+	case c.AutoIncrement && d == Postgres:
+		parts = append(parts, typ, "GENERATED BY DEFAULT AS IDENTITY")
+	case c.AutoIncrement && d == MySQL:
+		parts = append(parts, typ, "AUTO_INCREMENT")
+	default:
+		parts = append(parts, typ)
+	}
+
+	if c.PrimaryKey && inlinePK {
+		parts = append(parts, "PRIMARY KEY")
+		if c.AutoIncrement {
+			parts = append(parts, "AUTOINCREMENT")
+		}
+	}
+
+	if c.NotNull {
+		parts = append(parts, "NOT NULL")
+	}
+
+	if c.Unique {
+		parts = append(parts, "UNIQUE")
+	}
+
+	if c.HasDefault {
+		parts = append(parts, "DEFAULT "+c.Default)
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+func (d Dialect) quoteAll(idents []string) string {
+	quoted := make([]string, 0, len(idents))
+	for _, ident := range idents {
+		quoted = append(quoted, d.Quote(ident))
+	}
+
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,119 @@
+package sqlgen_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ravsii/textra"
+	"github.com/ravsii/textra/sqlgen"
+)
+
+type User struct {
+	ID        int64      `db:"id,pk,autoincrement"`
+	Email     string     `db:"email,unique,type=varchar(320)"`
+	Name      *string    `db:"name"`
+	OrgID     int        `db:",index=idx_users_org"`
+	Role      string     `db:"role,index=idx_users_org,default='user'"`
+	Avatar    []byte     `db:"avatar,null"`
+	CreatedAt time.Time  `db:"created_at"`
+	DeletedAt *time.Time `sql:"deleted_at,index"`
+	Ignored   string     `db:"-"`
+	NoTag     string
+}
+
+func TestCreateTable(t *testing.T) {
+	s := textra.Extract((*User)(nil))
+
+	tests := []struct {
+		dialect sqlgen.Dialect
+		want    string
+	}{
+		{sqlgen.Postgres, `CREATE TABLE "users" (
+  "id" BIGINT GENERATED BY DEFAULT AS IDENTITY NOT NULL,
+  "email" varchar(320) NOT NULL UNIQUE,
+  "name" TEXT,
+  "org_id" BIGINT NOT NULL,
+  "role" TEXT NOT NULL DEFAULT 'user',
+  "avatar" BYTEA,
+  "created_at" TIMESTAMPTZ NOT NULL,
+  "deleted_at" TIMESTAMPTZ,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_users_org" ON "users" ("org_id", "role");
+CREATE INDEX "idx_users_deleted_at" ON "users" ("deleted_at");
+`},
+		{sqlgen.MySQL, "CREATE TABLE `users` (\n" +
+			"  `id` BIGINT AUTO_INCREMENT NOT NULL,\n" +
+			"  `email` varchar(320) NOT NULL UNIQUE,\n" +
+			"  `name` VARCHAR(255),\n" +
+			"  `org_id` BIGINT NOT NULL,\n" +
+			"  `role` VARCHAR(255) NOT NULL DEFAULT 'user',\n" +
+			"  `avatar` BLOB,\n" +
+			"  `created_at` DATETIME NOT NULL,\n" +
+			"  `deleted_at` DATETIME,\n" +
+			"  PRIMARY KEY (`id`)\n" +
+			");\n" +
+			"CREATE INDEX `idx_users_org` ON `users` (`org_id`, `role`);\n" +
+			"CREATE INDEX `idx_users_deleted_at` ON `users` (`deleted_at`);\n"},
+		{sqlgen.SQLite, `CREATE TABLE "users" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  "email" varchar(320) NOT NULL UNIQUE,
+  "name" TEXT,
+  "org_id" INTEGER NOT NULL,
+  "role" TEXT NOT NULL DEFAULT 'user',
+  "avatar" BLOB,
+  "created_at" DATETIME NOT NULL,
+  "deleted_at" DATETIME
+);
+CREATE INDEX "idx_users_org" ON "users" ("org_id", "role");
+CREATE INDEX "idx_users_deleted_at" ON "users" ("deleted_at");
+`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.dialect.String(), func(t *testing.T) {
+			got, err := sqlgen.CreateTable(tt.dialect, "users", s)
+			if err != nil {
+				t.Fatalf("CreateTable() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("CreateTable() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateTableErrors(t *testing.T) {
+	type Unmapped struct {
+		Tags map[string]string `db:"tags"`
+	}
+
+	type UnknownOption struct {
+		ID int `db:"id,primary"`
+	}
+
+	type NoColumns struct {
+		ID int
+	}
+
+	tests := []struct {
+		name  string
+		input interface{}
+	}{
+		{"unmapped type", (*Unmapped)(nil)},
+		{"unknown option", (*UnknownOption)(nil)},
+		{"no columns", (*NoColumns)(nil)},
+	}
+
+	for _, tt := range tests {
+		if _, err := sqlgen.CreateTable(sqlgen.Postgres, "t", textra.Extract(tt.input)); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+
+	if _, err := sqlgen.CreateTable(sqlgen.Dialect(42), "users", textra.Extract((*User)(nil))); err == nil {
+		t.Errorf("unknown dialect: expected an error")
+	}
+}
@@ -0,0 +1,190 @@
+// Package sqlgen generates CREATE TABLE statements from a textra.Struct,
+// using db (or sql) tags, like
+//
+//	`db:"email,unique,type=varchar(320)"`.
+//
+// The tag's value is a column name (a snake_cased field's name, if it's
+// empty), supported options are:
+//
+//	pk                  a part of the primary key
+//	autoincrement       an auto-incremented column
+//	unique              a unique column
+//	index[=name]        a part of an index, fields with the same name form a
+//	                    composite index
+//	unique_index[=name] same as index, but unique
+//	type=...            overrides the SQL type, commas are allowed inside
+//	                    parentheses, like type=numeric(10,2)
+//	default=...         a default value, written as is (without commas
+//	                    outside parentheses)
+//	null, notnull       override nullability, which is NOT NULL for
+//	                    non-pointer fields
+//
+// Fields without db and sql tags, as well as ignored ("-") ones, are skipped.
+package sqlgen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ravsii/textra"
+	"github.com/ravsii/textra/internal/naming"
+)
+
+// TagKeys lists tags which describe columns, in order of precedence.
+var TagKeys = []string{"db", "sql"}
+
+// Table is a dialect-independent table definition.
+type Table struct {
+	Name string
+	// Columns are in the same order as the Struct's fields.
+	Columns []Column
+	Indexes []Index
+}
+
+// Column is a single column of a Table.
+type Column struct {
+	Name string
+	// Field is a struct field's name.
+	Field string
+	// GoType is a field's type without the pointer, like "int64".
+	GoType string
+	// Type is an SQL type from the type option, which overrides the type
+	// mapped from GoType.
+	Type          string
+	NotNull       bool
+	PrimaryKey    bool
+	AutoIncrement bool
+	Unique        bool
+	Default       string
+	HasDefault    bool
+}
+
+// Index is a (possibly composite) index of a Table.
+type Index struct {
+	Name    string
+	Unique  bool
+	Columns []string
+}
+
+// NewTable returns a table definition of s. Unnamed indexes are named
+// "idx_<table>_<column>".
+func NewTable(name string, s textra.Struct) (Table, error) {
+	table := Table{Name: name}
+	byName := make(map[string]int)
+
+	for _, field := range s {
+		tag, ok := columnTag(field)
+		if !ok || tag.Ignored() {
+			continue
+		}
+
+		column := Column{
+			Name:    tag.Value,
+			Field:   field.Name,
+			GoType:  strings.TrimPrefix(field.Type, "*"),
+			NotNull: !strings.HasPrefix(field.Type, "*"),
+		}
+
+		if column.Name == "" {
+			column.Name = naming.Snake(field.Name)
+		}
+
+		for _, opt := range joinParens(tag.Optional) {
+			key, value := splitOption(opt)
+
+			switch key {
+			case "pk":
+				column.PrimaryKey, column.NotNull = true, true
+			case "autoincrement":
+				column.AutoIncrement = true
+			case "unique":
+				column.Unique = true
+			case "type":
+				column.Type = value
+			case "default":
+				column.Default, column.HasDefault = value, true
+			case "null":
+				column.NotNull = false
+			case "notnull":
+				column.NotNull = true
+			case "index", "unique_index":
+				indexName := value
+				if indexName == "" {
+					indexName = "idx_" + name + "_" + column.Name
+				}
+
+				i, ok := byName[indexName]
+				if !ok {
+					i = len(table.Indexes)
+					byName[indexName] = i
+					table.Indexes = append(table.Indexes, Index{Name: indexName})
+				}
+
+				table.Indexes[i].Unique = table.Indexes[i].Unique || key == "unique_index"
+				table.Indexes[i].Columns = append(table.Indexes[i].Columns, column.Name)
+			case "":
+			default:
+				return Table{}, fmt.Errorf("sqlgen: %s: unknown option %q", field.Name, key)
+			}
+		}
+
+		table.Columns = append(table.Columns, column)
+	}
+
+	if len(table.Columns) == 0 {
+		return Table{}, fmt.Errorf("sqlgen: table %s has no columns", name)
+	}
+
+	return table, nil
+}
+
+// PrimaryKey returns names of primary key columns.
+func (t Table) PrimaryKey() []string {
+	pk := make([]string, 0)
+
+	for _, c := range t.Columns {
+		if c.PrimaryKey {
+			pk = append(pk, c.Name)
+		}
+	}
+
+	return pk
+}
+
+// joinParens joins options split by commas inside parentheses, like
+// "type=numeric(10" and "2)".
+func joinParens(opts []string) []string {
+	joined := make([]string, 0, len(opts))
+	depth := 0
+
+	for _, opt := range opts {
+		if depth > 0 {
+			joined[len(joined)-1] += "," + opt
+		} else {
+			joined = append(joined, opt)
+		}
+
+		depth += strings.Count(opt, "(") - strings.Count(opt, ")")
+	}
+
+	return joined
+}
+
+func columnTag(field textra.Field) (textra.Tag, bool) {
+	for _, key := range TagKeys {
+		if tag, ok := field.Tags.ByName(key); ok {
+			return tag, true
+		}
+	}
+
+	return textra.Tag{}, false
+}
+
+func splitOption(opt string) (string, string) {
+	split := strings.SplitN(opt, "=", 2)
+	if len(split) == 1 {
+		return strings.ToLower(strings.TrimSpace(split[0])), ""
+	}
+
+	return strings.ToLower(strings.TrimSpace(split[0])), strings.TrimSpace(split[1])
+}
@@ -0,0 +1,45 @@
+package sqlgen_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ravsii/textra"
+	"github.com/ravsii/textra/sqlgen"
+)
+
+func TestNewTable(t *testing.T) {
+	type Membership struct {
+		UserID  int     `db:"user_id,pk"`
+		GroupID int     `db:"group_id,pk,unique_index=uq_member"`
+		Slot    *int    `db:"slot,notnull,unique_index=uq_member"`
+		Price   float64 `db:"price,type=numeric(10, 2),default=0"`
+		Note    *string `db:""`
+	}
+
+	table, err := sqlgen.NewTable("memberships", textra.Extract((*Membership)(nil)))
+	if err != nil {
+		t.Fatalf("NewTable() error = %v", err)
+	}
+
+	want := sqlgen.Table{
+		Name: "memberships",
+		Columns: []sqlgen.Column{
+			{Name: "user_id", Field: "UserID", GoType: "int", NotNull: true, PrimaryKey: true},
+			{Name: "group_id", Field: "GroupID", GoType: "int", NotNull: true, PrimaryKey: true},
+			{Name: "slot", Field: "Slot", GoType: "int", NotNull: true},
+			{Name: "price", Field: "Price", GoType: "float64", Type: "numeric(10,2)", NotNull: true, Default: "0", HasDefault: true},
+		},
+		Indexes: []sqlgen.Index{
+			{Name: "uq_member", Unique: true, Columns: []string{"group_id", "slot"}},
+		},
+	}
+
+	if !reflect.DeepEqual(table, want) {
+		t.Errorf("NewTable() = %+v, want %+v", table, want)
+	}
+
+	if pk := table.PrimaryKey(); !reflect.DeepEqual(pk, []string{"user_id", "group_id"}) {
+		t.Errorf("PrimaryKey() = %v", pk)
+	}
+}
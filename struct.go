@@ -1,5 +1,12 @@
 package textra
 
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
 // Struct represents a single struct.
 type Struct []Field
 
@@ -57,6 +64,120 @@ func (s Struct) ByTagNameAll(tags ...string) Struct {
 	return filtered
 }
 
+// ByTagPattern returns a slice of fields which contain a tag with a name
+// matching the glob pattern, like "validate*". See path.Match for the
+// syntax. A malformed pattern matches nothing.
+func (s Struct) ByTagPattern(pattern string) Struct {
+	return s.byTag(func(t Tag) bool {
+		ok, _ := path.Match(pattern, t.Tag)
+		return ok
+	})
+}
+
+// ByTagRegexp returns a slice of fields which contain a tag with a name
+// matching re.
+func (s Struct) ByTagRegexp(re *regexp.Regexp) Struct {
+	return s.byTag(func(t Tag) bool {
+		return re.MatchString(t.Tag)
+	})
+}
+
+// ByTagValuePattern returns a slice of fields which contain given tag with
+// a value matching the glob pattern, like "meta_*". See path.Match for the
+// syntax. A malformed pattern matches nothing.
+func (s Struct) ByTagValuePattern(tag, pattern string) Struct {
+	return s.byTag(func(t Tag) bool {
+		ok, _ := path.Match(pattern, t.Value)
+		return ok && t.Tag == tag
+	})
+}
+
+// ByTagValueRegexp returns a slice of fields which contain given tag with a
+// value matching re.
+func (s Struct) ByTagValueRegexp(tag string, re *regexp.Regexp) Struct {
+	return s.byTag(func(t Tag) bool {
+		return t.Tag == tag && re.MatchString(t.Value)
+	})
+}
+
+// ByOption returns a slice of fields which contain given tag with the
+// option, like ByOption("json", "omitempty"). See Tag.HasOption.
+func (s Struct) ByOption(tag, option string) Struct {
+	return s.byTag(func(t Tag) bool {
+		return t.Tag == tag && t.HasOption(option)
+	})
+}
+
+// WithoutTag returns a slice of fields which don't contain given tag.
+func (s Struct) WithoutTag(tag string) Struct {
+	return s.WithoutTagAny(tag)
+}
+
+// WithoutTagAny returns a slice of fields which contain none of the tags.
+func (s Struct) WithoutTagAny(tags ...string) Struct {
+	tagsUnique := toUniqueMap(tags...)
+
+	return s.FilterFunc(func(f Field) bool {
+		for _, t := range f.Tags {
+			if _, ok := tagsUnique[t.Tag]; ok {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+// byTag returns a slice of fields which contain at least one tag matching
+// fn.
+func (s Struct) byTag(fn func(Tag) bool) Struct {
+	return s.FilterFunc(func(f Field) bool {
+		for _, t := range f.Tags {
+			if fn(t) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// Duplicates returns fields which share the same value of the given tag,
+// grouped by that value. Only values used by more than one field are
+// returned.
+// Ignored ("-") tags are skipped and an empty value falls back to the field's
+// name. Embedded fields without a value are skipped, since their fields are
+// promoted, use ExtractStrict to check those as well.
+func (s Struct) Duplicates(tag string) map[string][]Field {
+	byValue := make(map[string][]Field)
+	for _, field := range s {
+		t, ok := field.Tags.ByName(tag)
+		if !ok || t.Ignored() {
+			continue
+		}
+
+		value := t.Value
+		if value == "" {
+			if field.Embedded {
+				continue
+			}
+
+			value = field.Name
+		}
+
+		byValue[value] = append(byValue[value], field)
+	}
+
+	dups := make(map[string][]Field)
+	for value, fields := range byValue {
+		if len(fields) > 1 {
+			dups[value] = fields
+		}
+	}
+
+	return dups
+}
+
 // Field returns a field by name.
 func (s Struct) Field(name string) (Field, bool) {
 	for _, field := range s {
@@ -123,6 +244,133 @@ func (s Struct) OnlyTag(name string) []FieldTag {
 	return filtered
 }
 
+// GroupBy groups fields by fn(field). Fields keep their order within a
+// group. Fields for which fn returns false aren't grouped.
+func (s Struct) GroupBy(fn func(Field) (string, bool)) map[string]Struct {
+	groups := make(map[string]Struct)
+	for _, field := range s {
+		if key, ok := fn(field); ok {
+			groups[key] = append(groups[key], field)
+		}
+	}
+
+	return groups
+}
+
+// SortBy returns a copy of s, stably sorted by less.
+func (s Struct) SortBy(less func(a, b Field) bool) Struct {
+	sorted := make(Struct, len(s))
+	copy(sorted, s)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return less(sorted[i], sorted[j])
+	})
+
+	return sorted
+}
+
+// SortByTag returns a copy of s, stably sorted by values of the given tag.
+// Integer values are compared as numbers and go before other values, which
+// are compared as strings. Fields without the tag go last.
+func (s Struct) SortByTag(tag string) Struct {
+	return s.SortBy(func(a, b Field) bool {
+		ta, okA := a.Tags.ByName(tag)
+		tb, okB := b.Tags.ByName(tag)
+
+		if !okA || !okB {
+			return okA && !okB
+		}
+
+		na, errA := strconv.Atoi(ta.Value)
+		nb, errB := strconv.Atoi(tb.Value)
+
+		switch {
+		case errA == nil && errB == nil:
+			return na < nb
+		case errA == nil || errB == nil:
+			return errA == nil
+		default:
+			return ta.Value < tb.Value
+		}
+	})
+}
+
+// Union returns fields of s followed by fields of other with names not
+// present in s.
+func (s Struct) Union(other Struct) Struct {
+	return s.union(other, fieldName)
+}
+
+// UnionByTag is like Union, but fields are compared by values of the given
+// tag. Fields without the tag are never equal.
+func (s Struct) UnionByTag(other Struct, tag string) Struct {
+	return s.union(other, tagValue(tag))
+}
+
+// Intersect returns fields of s with names present in other.
+func (s Struct) Intersect(other Struct) Struct {
+	return s.filterByKeys(other, fieldName, true)
+}
+
+// IntersectByTag is like Intersect, but fields are compared by values of
+// the given tag. Fields without the tag are never equal.
+func (s Struct) IntersectByTag(other Struct, tag string) Struct {
+	return s.filterByKeys(other, tagValue(tag), true)
+}
+
+// Difference returns fields of s with names not present in other.
+func (s Struct) Difference(other Struct) Struct {
+	return s.filterByKeys(other, fieldName, false)
+}
+
+// DifferenceByTag is like Difference, but fields are compared by values of
+// the given tag. Fields without the tag are never equal.
+func (s Struct) DifferenceByTag(other Struct, tag string) Struct {
+	return s.filterByKeys(other, tagValue(tag), false)
+}
+
+// fieldKey returns a key fields are compared by in set operations.
+type fieldKey func(Field) (string, bool)
+
+func fieldName(f Field) (string, bool) {
+	return f.Name, true
+}
+
+func tagValue(tag string) fieldKey {
+	return func(f Field) (string, bool) {
+		t, ok := f.Tags.ByName(tag)
+		return t.Value, ok
+	}
+}
+
+func (s Struct) keys(key fieldKey) map[string]bool {
+	keys := make(map[string]bool, len(s))
+	for _, field := range s {
+		if k, ok := key(field); ok {
+			keys[k] = true
+		}
+	}
+
+	return keys
+}
+
+func (s Struct) union(other Struct, key fieldKey) Struct {
+	union := make(Struct, 0, len(s)+len(other))
+	union = append(union, s...)
+
+	return append(union, other.filterByKeys(s, key, false)...)
+}
+
+// filterByKeys returns fields of s with keys present (or not) in other.
+func (s Struct) filterByKeys(other Struct, key fieldKey, present bool) Struct {
+	keys := other.keys(key)
+
+	return s.FilterFunc(func(f Field) bool {
+		k, ok := key(f)
+		return (ok && keys[k]) == present
+	})
+}
+
 func (s Struct) String() string {
 	var str string
 	for _, field := range s {
@@ -2,6 +2,7 @@ package textra_test
 
 import (
 	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/ravsii/textra"
@@ -38,9 +39,9 @@ func TestByTagName(t *testing.T) {
 				Name: "Tag2",
 				Type: "struct",
 				Tags: textra.Tags{
-					{"json", "tag2", nil},
-					{"pg", "tag2", nil},
-					{"sql", "tag2", []string{"pk"}},
+					{Tag: "json", Value: "tag2"},
+					{Tag: "pg", Value: "tag2"},
+					{Tag: "sql", Value: "tag2", Optional: []string{"pk"}, Text: "tag2, pk"},
 				},
 			},
 		}},
@@ -49,26 +50,26 @@ func TestByTagName(t *testing.T) {
 				Name: "Tag2",
 				Type: "struct",
 				Tags: textra.Tags{
-					{"json", "tag2", nil},
-					{"pg", "tag2", nil},
-					{"sql", "tag2", []string{"pk"}},
+					{Tag: "json", Value: "tag2"},
+					{Tag: "pg", Value: "tag2"},
+					{Tag: "sql", Value: "tag2", Optional: []string{"pk"}, Text: "tag2, pk"},
 				},
 			},
 			{
 				Name: "Tag3",
 				Type: "struct",
 				Tags: textra.Tags{
-					{"json", "tag3", nil},
-					{"sql", "tag3", []string{"pk"}},
+					{Tag: "json", Value: "tag3"},
+					{Tag: "sql", Value: "tag3", Optional: []string{"pk"}, Text: "tag3, pk"},
 				},
 			},
 			{
 				Name: "Tag4",
 				Type: "struct",
 				Tags: textra.Tags{
-					{"json", "tag4", nil},
-					{"gorm", "", []string{"pk"}},
-					{"sql", "tag4", []string{"pk"}},
+					{Tag: "json", Value: "tag4"},
+					{Tag: "gorm", Value: "", Optional: []string{"pk"}},
+					{Tag: "sql", Value: "tag4", Optional: []string{"pk"}, Text: "tag4, pk"},
 				},
 			},
 		}},
@@ -107,18 +108,18 @@ func TestByTagNameAny(t *testing.T) {
 				Name: "Tag2",
 				Type: "struct",
 				Tags: textra.Tags{
-					{"json", "tag2", nil},
-					{"pg", "tag2", nil},
-					{"sql", "tag2", []string{"pk"}},
+					{Tag: "json", Value: "tag2"},
+					{Tag: "pg", Value: "tag2"},
+					{Tag: "sql", Value: "tag2", Optional: []string{"pk"}, Text: "tag2, pk"},
 				},
 			},
 			{
 				Name: "Tag4",
 				Type: "struct",
 				Tags: textra.Tags{
-					{"json", "tag4", nil},
-					{"gorm", "", []string{"pk"}},
-					{"sql", "tag4", []string{"pk"}},
+					{Tag: "json", Value: "tag4"},
+					{Tag: "gorm", Value: "", Optional: []string{"pk"}},
+					{Tag: "sql", Value: "tag4", Optional: []string{"pk"}, Text: "tag4, pk"},
 				},
 			},
 		}},
@@ -127,26 +128,26 @@ func TestByTagNameAny(t *testing.T) {
 				Name: "Tag2",
 				Type: "struct",
 				Tags: textra.Tags{
-					{"json", "tag2", nil},
-					{"pg", "tag2", nil},
-					{"sql", "tag2", []string{"pk"}},
+					{Tag: "json", Value: "tag2"},
+					{Tag: "pg", Value: "tag2"},
+					{Tag: "sql", Value: "tag2", Optional: []string{"pk"}, Text: "tag2, pk"},
 				},
 			},
 			{
 				Name: "Tag3",
 				Type: "struct",
 				Tags: textra.Tags{
-					{"json", "tag3", nil},
-					{"sql", "tag3", []string{"pk"}},
+					{Tag: "json", Value: "tag3"},
+					{Tag: "sql", Value: "tag3", Optional: []string{"pk"}, Text: "tag3, pk"},
 				},
 			},
 			{
 				Name: "Tag4",
 				Type: "struct",
 				Tags: textra.Tags{
-					{"json", "tag4", nil},
-					{"gorm", "", []string{"pk"}},
-					{"sql", "tag4", []string{"pk"}},
+					{Tag: "json", Value: "tag4"},
+					{Tag: "gorm", Value: "", Optional: []string{"pk"}},
+					{Tag: "sql", Value: "tag4", Optional: []string{"pk"}, Text: "tag4, pk"},
 				},
 			},
 		}},
@@ -186,9 +187,9 @@ func TestByTagNameAll(t *testing.T) {
 					Name: "Tag2",
 					Type: "struct",
 					Tags: textra.Tags{
-						{"json", "tag2", nil},
-						{"pg", "tag2", nil},
-						{"sql", "tag2", []string{"pk"}},
+						{Tag: "json", Value: "tag2"},
+						{Tag: "pg", Value: "tag2"},
+						{Tag: "sql", Value: "tag2", Optional: []string{"pk"}, Text: "tag2, pk"},
 					},
 				},
 			},
@@ -199,9 +200,9 @@ func TestByTagNameAll(t *testing.T) {
 					Name: "Tag4",
 					Type: "struct",
 					Tags: textra.Tags{
-						{"json", "tag4", nil},
-						{"gorm", "", []string{"pk"}},
-						{"sql", "tag4", []string{"pk"}},
+						{Tag: "json", Value: "tag4"},
+						{Tag: "gorm", Value: "", Optional: []string{"pk"}},
+						{Tag: "sql", Value: "tag4", Optional: []string{"pk"}, Text: "tag4, pk"},
 					},
 				},
 			}},
@@ -222,6 +223,86 @@ func TestByTagNameAll(t *testing.T) {
 	}
 }
 
+func TestPatternFilters(t *testing.T) {
+	type TestPattern struct {
+		ID      int    `db:"id" validate:"required"`
+		Meta    string `db:"meta_info" validate_create:"required"`
+		Updated string `db:"meta_updated,omitempty" validate_update:"omitempty"`
+		Name    string `json:"name,omitempty"`
+		Note    string
+	}
+
+	data := textra.Extract((*TestPattern)(nil))
+
+	tests := []struct {
+		name string
+		got  textra.Struct
+		want []string
+	}{
+		{"tag pattern", data.ByTagPattern("validate*"), []string{"ID", "Meta", "Updated"}},
+		{"tag pattern suffix", data.ByTagPattern("validate_*"), []string{"Meta", "Updated"}},
+		{"bad tag pattern", data.ByTagPattern("["), []string{}},
+		{"tag regexp", data.ByTagRegexp(regexp.MustCompile(`^validate_(create|update)$`)), []string{"Meta", "Updated"}},
+		{"value pattern", data.ByTagValuePattern("db", "meta_*"), []string{"Meta", "Updated"}},
+		{"value pattern other tag", data.ByTagValuePattern("json", "meta_*"), []string{}},
+		{"value regexp", data.ByTagValueRegexp("db", regexp.MustCompile(`_updated$`)), []string{"Updated"}},
+		{"option", data.ByOption("db", "omitempty"), []string{"Updated"}},
+		{"option other tag", data.ByOption("json", "omitempty"), []string{"Name"}},
+		{"without tag", data.WithoutTag("db"), []string{"Name", "Note"}},
+		{"without tag any", data.WithoutTagAny("json", "validate"), []string{"Meta", "Updated", "Note"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldNames(tt.got); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	type Tester struct {
+		ID      int      `db:"id"    json:"id"`
+		UserID  int      `db:"id"    json:"user_id"`
+		Name    string   `db:",omitempty"`
+		Title   string   `db:"Name"`
+		Ignored string   `db:"-"     json:"-"`
+		Other   string   `db:"-"     json:"-"`
+		Empty   struct{} `db:"empty"`
+	}
+
+	data := textra.Extract((*Tester)(nil))
+
+	tests := []struct {
+		tagName string
+		want    map[string][]string
+	}{
+		{"db", map[string][]string{"id": {"ID", "UserID"}, "Name": {"Name", "Title"}}},
+		{"json", map[string][]string{}},
+		{"nonexistent", map[string][]string{}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.tagName, func(t *testing.T) {
+			t.Parallel()
+
+			got := make(map[string][]string)
+			for value, fields := range data.Duplicates(tt.tagName) {
+				for _, field := range fields {
+					got[value] = append(got[value], field.Name)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Duplicates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFilterFunc(t *testing.T) {
 	type Tester struct {
 		Tag1 struct{} `json:"tag1"`
@@ -239,15 +320,15 @@ func TestFilterFunc(t *testing.T) {
 					Name: "Tag1",
 					Type: "struct",
 					Tags: textra.Tags{
-						{"json", "tag1", nil},
+						{Tag: "json", Value: "tag1"},
 					},
 				},
 				textra.Field{
 					Name: "Tag2",
 					Type: "struct",
 					Tags: textra.Tags{
-						{"json", "tag2", nil},
-						{"sql", "tag2", []string{"pk"}},
+						{Tag: "json", Value: "tag2"},
+						{Tag: "sql", Value: "tag2", Optional: []string{"pk"}, Text: "tag2, pk"},
 					},
 				},
 			},
@@ -294,14 +375,14 @@ func TestRemoveEmpty(t *testing.T) {
 				Name: "Tag1",
 				Type: "struct",
 				Tags: textra.Tags{
-					{"json", "tag1", nil},
+					{Tag: "json", Value: "tag1"},
 				},
 			},
 			{
 				Name: "Tag2",
 				Type: "struct",
 				Tags: textra.Tags{
-					{"json", "tag2", nil},
+					{Tag: "json", Value: "tag2"},
 				},
 			},
 		}},
@@ -310,7 +391,7 @@ func TestRemoveEmpty(t *testing.T) {
 				Name: "Tag1",
 				Type: "struct",
 				Tags: textra.Tags{
-					{"json", "tag1", nil},
+					{Tag: "json", Value: "tag1"},
 				},
 			},
 		}},
@@ -426,33 +507,33 @@ func TestRemoveFields(t *testing.T) {
 					Name: "Tag1",
 					Type: "struct",
 					Tags: textra.Tags{
-						{"json", "tag1", nil},
+						{Tag: "json", Value: "tag1"},
 					},
 				},
 				{
 					Name: "Tag2",
 					Type: "struct",
 					Tags: textra.Tags{
-						{"json", "tag2", nil},
-						{"pg", "tag2", nil},
-						{"sql", "tag2", []string{"pk"}},
+						{Tag: "json", Value: "tag2"},
+						{Tag: "pg", Value: "tag2"},
+						{Tag: "sql", Value: "tag2", Optional: []string{"pk"}, Text: "tag2, pk"},
 					},
 				},
 				{
 					Name: "Tag3",
 					Type: "struct",
 					Tags: textra.Tags{
-						{"json", "tag3", nil},
-						{"sql", "tag3", []string{"pk"}},
+						{Tag: "json", Value: "tag3"},
+						{Tag: "sql", Value: "tag3", Optional: []string{"pk"}, Text: "tag3, pk"},
 					},
 				},
 				{
 					Name: "Tag4",
 					Type: "struct",
 					Tags: textra.Tags{
-						{"json", "tag4", nil},
-						{"gorm", "", []string{"pk"}},
-						{"sql", "tag4", []string{"pk"}},
+						{Tag: "json", Value: "tag4"},
+						{Tag: "gorm", Value: "", Optional: []string{"pk"}},
+						{Tag: "sql", Value: "tag4", Optional: []string{"pk"}, Text: "tag4, pk"},
 					},
 				},
 			},
@@ -463,26 +544,26 @@ func TestRemoveFields(t *testing.T) {
 					Name: "Tag2",
 					Type: "struct",
 					Tags: textra.Tags{
-						{"json", "tag2", nil},
-						{"pg", "tag2", nil},
-						{"sql", "tag2", []string{"pk"}},
+						{Tag: "json", Value: "tag2"},
+						{Tag: "pg", Value: "tag2"},
+						{Tag: "sql", Value: "tag2", Optional: []string{"pk"}, Text: "tag2, pk"},
 					},
 				},
 				{
 					Name: "Tag3",
 					Type: "struct",
 					Tags: textra.Tags{
-						{"json", "tag3", nil},
-						{"sql", "tag3", []string{"pk"}},
+						{Tag: "json", Value: "tag3"},
+						{Tag: "sql", Value: "tag3", Optional: []string{"pk"}, Text: "tag3, pk"},
 					},
 				},
 				{
 					Name: "Tag4",
 					Type: "struct",
 					Tags: textra.Tags{
-						{"json", "tag4", nil},
-						{"gorm", "", []string{"pk"}},
-						{"sql", "tag4", []string{"pk"}},
+						{Tag: "json", Value: "tag4"},
+						{Tag: "gorm", Value: "", Optional: []string{"pk"}},
+						{Tag: "sql", Value: "tag4", Optional: []string{"pk"}, Text: "tag4, pk"},
 					},
 				},
 			},
@@ -493,15 +574,15 @@ func TestRemoveFields(t *testing.T) {
 					Name: "Tag1",
 					Type: "struct",
 					Tags: textra.Tags{
-						{"json", "tag1", nil},
+						{Tag: "json", Value: "tag1"},
 					},
 				},
 				{
 					Name: "Tag3",
 					Type: "struct",
 					Tags: textra.Tags{
-						{"json", "tag3", nil},
-						{"sql", "tag3", []string{"pk"}},
+						{Tag: "json", Value: "tag3"},
+						{Tag: "sql", Value: "tag3", Optional: []string{"pk"}, Text: "tag3, pk"},
 					},
 				},
 			},
@@ -512,7 +593,7 @@ func TestRemoveFields(t *testing.T) {
 					Name: "Tag1",
 					Type: "struct",
 					Tags: textra.Tags{
-						{"json", "tag1", nil},
+						{Tag: "json", Value: "tag1"},
 					},
 				},
 			},
@@ -532,6 +613,107 @@ func TestRemoveFields(t *testing.T) {
 	}
 }
 
+func fieldNames(s textra.Struct) []string {
+	names := make([]string, 0, len(s))
+	for _, f := range s {
+		names = append(names, f.Name)
+	}
+
+	return names
+}
+
+func TestGroupBy(t *testing.T) {
+	type TestGroup struct {
+		A string `gorm:"index:idx_a"`
+		B string `gorm:"index:idx_b"`
+		C string `gorm:"index:idx_a"`
+		D string
+	}
+
+	data := textra.Extract((*TestGroup)(nil))
+	groups := data.GroupBy(func(f textra.Field) (string, bool) {
+		tag, ok := f.Tags.ByName("gorm")
+		return tag.Value, ok
+	})
+
+	want := map[string][]string{"index:idx_a": {"A", "C"}, "index:idx_b": {"B"}}
+	if len(groups) != len(want) {
+		t.Fatalf("GroupBy() = %v, want %v", groups, want)
+	}
+
+	for key, names := range want {
+		if got := fieldNames(groups[key]); !reflect.DeepEqual(got, names) {
+			t.Errorf("GroupBy()[%s] = %v, want %v", key, got, names)
+		}
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	type TestSort struct {
+		A string `order:"10"`
+		B string `order:"b"`
+		C string
+		D string `order:"2"`
+		E string `order:"a"`
+	}
+
+	data := textra.Extract((*TestSort)(nil))
+
+	if got, want := fieldNames(data.SortByTag("order")), []string{"D", "A", "E", "B", "C"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortByTag() = %v, want %v", got, want)
+	}
+
+	byName := data.SortBy(func(a, b textra.Field) bool { return a.Name > b.Name })
+	if got, want := fieldNames(byName), []string{"E", "D", "C", "B", "A"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortBy() = %v, want %v", got, want)
+	}
+
+	if got, want := fieldNames(data), []string{"A", "B", "C", "D", "E"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortBy() modified the struct: %v", got)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	type TestLeft struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+		Note  string
+	}
+
+	type TestRight struct {
+		ID       int    `json:"id"`
+		FullName string `json:"name"`
+		Phone    string `json:"phone"`
+		Note     string
+	}
+
+	left := textra.Extract((*TestLeft)(nil))
+	right := textra.Extract((*TestRight)(nil))
+
+	tests := []struct {
+		name string
+		got  textra.Struct
+		want []string
+	}{
+		{"union", left.Union(right), []string{"ID", "Name", "Email", "Note", "FullName", "Phone"}},
+		{"union by tag", left.UnionByTag(right, "json"), []string{"ID", "Name", "Email", "Note", "Phone", "Note"}},
+		{"intersect", left.Intersect(right), []string{"ID", "Note"}},
+		{"intersect by tag", left.IntersectByTag(right, "json"), []string{"ID", "Name"}},
+		{"difference", left.Difference(right), []string{"Name", "Email"}},
+		{"difference by tag", left.DifferenceByTag(right, "json"), []string{"Email", "Note"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldNames(tt.got); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestString(t *testing.T) {
 	type (
 		TestEmpty struct{}
@@ -1,7 +1,9 @@
 package textra
 
 import (
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Tags is a slice of tags.
@@ -18,6 +20,19 @@ func (t Tags) ByName(name string) (Tag, bool) {
 	return Tag{}, false
 }
 
+// ParseByName finds a tag by its name and parses it using a parser
+// registered for it. See Tag.Parse.
+func (t Tags) ParseByName(name string) (interface{}, bool, error) {
+	tag, ok := t.ByName(name)
+	if !ok {
+		return nil, false, nil
+	}
+
+	parsed, err := tag.Parse()
+
+	return parsed, true, err
+}
+
 func (t Tags) String() string {
 	tags := make([]string, 0, len(t))
 	for _, tag := range t {
@@ -40,25 +55,163 @@ type Tag struct {
 	// Optional will contain
 	// 	["pk", "omitempty"]
 	Optional []string `json:"optional,omitempty"`
+	// Text holds the value as it's written in the struct, if it differs
+	// from Value and Optional joined by commas. For
+	// 	`desc:"A user, or a bot"`
+	// Text is "A user, or a bot". Use Raw to get the value in any case.
+	Text string `json:"text,omitempty"`
 }
 
 // OmitEmpty returns true if t.Optional contains "omitempty".
 func (t Tag) OmitEmpty() bool {
-	m := toUniqueMap(t.Optional...)
-	_, ok := m["omitempty"]
+	return t.HasOption("omitempty")
+}
+
+// Option returns a value of an option, which is either a flag, like
+//
+//	`json:"id,omitempty"`
+//
+// or a key=value pair, like
+//
+//	`default:"-,default=5"`.
+//
+// Flags have an empty value. If the option is listed more than once,
+// the first one is returned.
+func (t Tag) Option(name string) (string, bool) {
+	for _, opt := range t.Optional {
+		key, value := splitOption(opt)
+		if key == name {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// HasOption returns true if t.Optional contains an option with the given
+// name, either as a flag or as a key=value pair.
+func (t Tag) HasOption(name string) bool {
+	_, ok := t.Option(name)
 	return ok
 }
 
+// OptionInt returns an option's value parsed as an int.
+// False is returned if the option is missing or it's not a valid int.
+func (t Tag) OptionInt(name string) (int, bool) {
+	value, ok := t.Option(name)
+	if !ok {
+		return 0, false
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return i, true
+}
+
+// OptionBool returns an option's value parsed as a bool.
+// A flag without a value is treated as true.
+// False is returned if the option is missing or it's not a valid bool.
+func (t Tag) OptionBool(name string) (bool, bool) {
+	str, ok := t.Option(name)
+	if !ok {
+		return false, false
+	}
+
+	if str == "" {
+		return true, true
+	}
+
+	b, err := strconv.ParseBool(str)
+	if err != nil {
+		return false, false
+	}
+
+	return b, true
+}
+
+// OptionDuration returns an option's value parsed by time.ParseDuration.
+// False is returned if the option is missing or it's not a valid duration.
+func (t Tag) OptionDuration(name string) (time.Duration, bool) {
+	value, ok := t.Option(name)
+	if !ok {
+		return 0, false
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return d, true
+}
+
+// Options returns all options as a map of names to values.
+// Flags have an empty value. If the option is listed more than once,
+// the first one is kept.
+func (t Tag) Options() map[string]string {
+	options := make(map[string]string, len(t.Optional))
+	for _, opt := range t.Optional {
+		key, value := splitOption(opt)
+		if _, ok := options[key]; !ok {
+			options[key] = value
+		}
+	}
+
+	return options
+}
+
+// splitOption splits an option like "max=100" into its name and value.
+func splitOption(opt string) (string, string) {
+	split := strings.SplitN(opt, "=", 2)
+	if len(split) == 1 {
+		return strings.TrimSpace(split[0]), ""
+	}
+
+	return strings.TrimSpace(split[0]), strings.TrimSpace(split[1])
+}
+
 // Ignored is a shortcut for t.Value == "-".
 func (t Tag) Ignored() bool {
 	return t.Value == "-"
 }
 
-func (t Tag) String() string {
-	s := t.Tag + `:"` + t.Value
+// Raw returns the tag's value as it's written in the struct, which is Text
+// if it's set and matches Value and Optional, else Value and Optional
+// joined by commas.
+func (t Tag) Raw() string {
+	if t.Text != "" && newTag(t.Tag, t.Text).joined() == t.joined() {
+		return t.Text
+	}
+
+	return t.joined()
+}
+
+// joined returns Value and Optional joined by commas.
+func (t Tag) joined() string {
+	s := t.Value
 	for _, v := range t.Optional {
 		s += "," + v
 	}
-	s += `"`
+
 	return s
 }
+
+// Parse parses the tag using a parser registered for its name with
+// RegisterTagParser. For unknown tags DefaultTagParser is used, which
+// returns the Tag itself.
+func (t Tag) Parse() (interface{}, error) {
+	return LookupTagParser(t.Tag).ParseTag(t)
+}
+
+// String returns the tag as it's written in a struct, like
+//
+//	json:"name,omitempty"
+//
+// Spaces around commas are dropped. The value is quoted with strconv.Quote,
+// so it can be parsed back with ParseTags.
+func (t Tag) String() string {
+	return t.Tag + ":" + strconv.Quote(t.joined())
+}
@@ -3,6 +3,7 @@ package textra_test
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ravsii/textra"
 )
@@ -21,8 +22,8 @@ func TestByName(t *testing.T) {
 		tagFound  bool
 		tag       textra.Tag
 	}{
-		{"named", "Named", "json", true, textra.Tag{"json", "named", nil}},
-		{"named with space", "NamedSpaced", "json", true, textra.Tag{"json", "named spaced", nil}},
+		{"named", "Named", "json", true, textra.Tag{Tag: "json", Value: "named"}},
+		{"named with space", "NamedSpaced", "json", true, textra.Tag{Tag: "json", Value: "named spaced"}},
 		{"empty", "Empty", "json", false, textra.Tag{}},
 	}
 
@@ -172,3 +173,104 @@ func TestTagsString(t *testing.T) {
 		}
 	}
 }
+
+func TestOptions(t *testing.T) {
+	type Tester struct {
+		Field struct{} `opt:"name,omitempty,default=5,max = 100,required=yes,timeout=1m30s,default=6"`
+	}
+
+	field, _ := textra.Extract(Tester{}).Field("Field")
+	tag, _ := field.Tags.ByName("opt")
+
+	wantOptions := map[string]string{
+		"omitempty": "",
+		"default":   "5",
+		"max":       "100",
+		"required":  "yes",
+		"timeout":   "1m30s",
+	}
+	if got := tag.Options(); !reflect.DeepEqual(got, wantOptions) {
+		t.Errorf("Options() = %v, want %v", got, wantOptions)
+	}
+
+	if v, ok := tag.Option("default"); !ok || v != "5" {
+		t.Errorf("Option(default) = %q, %t, want %q, true", v, ok, "5")
+	}
+
+	if !tag.HasOption("omitempty") || !tag.OmitEmpty() || tag.HasOption("name") {
+		t.Errorf("HasOption: got wrong result")
+	}
+
+	if v, ok := tag.OptionInt("max"); !ok || v != 100 {
+		t.Errorf("OptionInt(max) = %d, %t, want 100, true", v, ok)
+	}
+
+	if _, ok := tag.OptionInt("timeout"); ok {
+		t.Errorf("OptionInt(timeout) should fail")
+	}
+
+	if v, ok := tag.OptionBool("omitempty"); !ok || !v {
+		t.Errorf("OptionBool(omitempty) = %t, %t, want true, true", v, ok)
+	}
+
+	if _, ok := tag.OptionBool("required"); ok {
+		t.Errorf("OptionBool(required) should fail")
+	}
+
+	if v, ok := tag.OptionDuration("timeout"); !ok || v != 90*time.Second {
+		t.Errorf("OptionDuration(timeout) = %s, %t, want 1m30s, true", v, ok)
+	}
+
+	if _, ok := tag.OptionDuration("missing"); ok {
+		t.Errorf("OptionDuration(missing) should fail")
+	}
+}
+
+func TestTagRaw(t *testing.T) {
+	type Tester struct {
+		Plain   struct{} `json:"id,omitempty"`
+		Spaced  struct{} `sql:"id, pk"`
+		Text    struct{} `desc:"A user, or a bot"`
+		Default struct{} `default:"-,default=5"`
+	}
+
+	testCases := []struct {
+		testName  string
+		fieldName string
+		tagName   string
+		want      string
+	}{
+		{"plain", "Plain", "json", "id,omitempty"},
+		{"spaced", "Spaced", "sql", "id, pk"},
+		{"text", "Text", "desc", "A user, or a bot"},
+		{"default", "Default", "default", "-,default=5"},
+	}
+
+	data := textra.Extract(Tester{})
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		field, _ := data.Field(testCase.fieldName)
+		tag, _ := field.Tags.ByName(testCase.tagName)
+
+		if got := tag.Raw(); got != testCase.want {
+			t.Errorf("%s: got %q want %q", testCase.testName, got, testCase.want)
+		}
+	}
+
+	field, _ := data.Field("Default")
+	tag, _ := field.Tags.ByName("default")
+
+	if v, ok := tag.Option("default"); !ok || v != "5" {
+		t.Errorf("Option(default) = %q, %t, want %q, true", v, ok, "5")
+	}
+
+	field, _ = data.Field("Text")
+	tag, _ = field.Tags.ByName("desc")
+	tag.Value = "A bot"
+
+	if got, want := tag.Raw(), "A bot,or a bot"; got != want {
+		t.Errorf("changed tag: got %q want %q", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+package textra
+
+import (
+	"sync"
+)
+
+// TagParser parses a single tag into a structured, tag-specific value.
+// Parsers are registered by tag name with RegisterTagParser.
+type TagParser interface {
+	ParseTag(tag Tag) (interface{}, error)
+}
+
+// TagParserFunc is an adapter to allow the use of ordinary functions as
+// a TagParser.
+type TagParserFunc func(tag Tag) (interface{}, error)
+
+// ParseTag calls f(tag).
+func (f TagParserFunc) ParseTag(tag Tag) (interface{}, error) {
+	return f(tag)
+}
+
+// DefaultTagParser is used for tags without a registered parser.
+// It returns the Tag itself, since it's already split by commas into Value
+// and Optional.
+var DefaultTagParser TagParser = TagParserFunc(func(tag Tag) (interface{}, error) {
+	return tag, nil
+})
+
+var (
+	tagParsersMu sync.RWMutex
+	tagParsers   = map[string]TagParser{
+		"gorm":         gormTagParser,
+		"validate":     validateTagParser,
+		"protobuf":     protobufTagParser,
+		"protobuf_key": protobufTagParser,
+		"protobuf_val": protobufTagParser,
+		"xml":          xmlTagParser,
+	}
+)
+
+// RegisterTagParser registers a parser for tags with the given name,
+// replacing the previous one, if any. Passing a nil parser removes the
+// registration, so DefaultTagParser is used instead.
+// It's safe to call it concurrently.
+func RegisterTagParser(name string, parser TagParser) {
+	tagParsersMu.Lock()
+	defer tagParsersMu.Unlock()
+
+	if parser == nil {
+		delete(tagParsers, name)
+		return
+	}
+
+	tagParsers[name] = parser
+}
+
+// LookupTagParser returns a parser registered for the given tag name,
+// or DefaultTagParser.
+func LookupTagParser(name string) TagParser {
+	tagParsersMu.RLock()
+	defer tagParsersMu.RUnlock()
+
+	if parser, ok := tagParsers[name]; ok {
+		return parser
+	}
+
+	return DefaultTagParser
+}
@@ -0,0 +1,69 @@
+package textra_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ravsii/textra"
+)
+
+func TestRegisterTagParser(t *testing.T) {
+	type Tester struct {
+		Name string `json:"name,omitempty" kv:"column:name;type:varchar(100)"`
+		Bad  string `kv:"column"`
+		None string
+	}
+
+	textra.RegisterTagParser("kv", textra.TagParserFunc(func(tag textra.Tag) (interface{}, error) {
+		parsed := make(map[string]string)
+		for _, part := range strings.Split(tag.Raw(), ";") {
+			kv := strings.SplitN(part, ":", 2)
+			if len(kv) != 2 {
+				return nil, errors.New("missing colon")
+			}
+
+			parsed[kv[0]] = kv[1]
+		}
+
+		return parsed, nil
+	}))
+	defer textra.RegisterTagParser("kv", nil)
+
+	data := textra.Extract((*Tester)(nil))
+
+	testCases := []struct {
+		name      string
+		fieldName string
+		tagName   string
+		want      interface{}
+		wantFound bool
+		wantErr   bool
+	}{
+		{"registered", "Name", "kv", map[string]string{"column": "name", "type": "varchar(100)"}, true, false},
+		{"default", "Name", "json", textra.Tag{Tag: "json", Value: "name", Optional: []string{"omitempty"}}, true, false},
+		{"error", "Bad", "kv", nil, true, true},
+		{"not found", "None", "kv", nil, false, false},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		field, _ := data.Field(testCase.fieldName)
+		got, found, err := field.Tags.ParseByName(testCase.tagName)
+
+		if found != testCase.wantFound || (err != nil) != testCase.wantErr {
+			t.Errorf("%s: found %t, err %v", testCase.name, found, err)
+			continue
+		}
+
+		if err == nil && !reflect.DeepEqual(got, testCase.want) {
+			t.Errorf("%s: got %v want %v", testCase.name, got, testCase.want)
+		}
+	}
+
+	if _, ok := textra.LookupTagParser("kv").(textra.TagParserFunc); !ok {
+		t.Errorf("LookupTagParser(kv) should return the registered parser")
+	}
+}
@@ -0,0 +1,8 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+type Generator struct {
+	Out string `json:"out"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+type Base struct {
+	// ID is a primary key.
+	//
+	// It's never reused.
+	ID int `json:"id" db:"id,pk"`
+}
+
+type User struct {
+	_          struct{}          `table:"users"`
+	*Base                        // Base is shared by all models.
+	Name, Nick string            `json:"name"` // display names
+	Born       time.Time         `json:"born"`
+	Meta       struct{ A int }   `json:"meta"`
+	Any        interface{}       `json:"any"`
+	Labels     map[string][]byte `json:"labels,omitempty"`
+	Ptr        *string
+}
+
+type NotAStruct int
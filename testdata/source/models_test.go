@@ -0,0 +1,5 @@
+package models
+
+type Ignored struct {
+	A int
+}
@@ -0,0 +1,8 @@
+//go:build textra_legacy
+// +build textra_legacy
+
+package models
+
+type User struct {
+	Legacy int `json:"legacy"`
+}
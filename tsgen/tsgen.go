@@ -0,0 +1,299 @@
+// Package tsgen generates TypeScript interfaces from Go structs, using
+// json tags for property names.
+//
+// A ts tag overrides a property's type or skips it:
+//
+//	`ts:"type=Date"`
+//	`ts:"type=Record<string, number>"`
+//	`ts:"-"`
+//
+// The type is everything after "type=", so it must be the last option.
+package tsgen
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ravsii/textra"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	identRegexp = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+)
+
+// Generate returns exported TypeScript interfaces for the given structs
+// (or pointers to structs) and all the named structs they use. Each
+// interface is emitted once, in order of appearance.
+//
+// Untagged embedded structs become "extends" clauses, pointers and
+// omitempty fields are optional, slices become arrays and maps become
+// Record<K, V>.
+func Generate(values ...interface{}) (string, error) {
+	g := &generator{
+		names: make(map[reflect.Type]string),
+		taken: make(map[string]bool),
+	}
+
+	for _, v := range values {
+		typ := reflect.TypeOf(v)
+		for typ != nil && typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		if typ == nil || typ.Kind() != reflect.Struct || typ.Name() == "" {
+			return "", fmt.Errorf("tsgen: %v is not a named struct", typ)
+		}
+
+		if _, err := g.named(typ); err != nil {
+			return "", err
+		}
+	}
+
+	return strings.Join(g.decls, "\n"), nil
+}
+
+type generator struct {
+	names map[reflect.Type]string
+	taken map[string]bool
+	decls []string
+}
+
+// named returns a name of a named struct's interface, declaring it first,
+// if needed.
+func (g *generator) named(typ reflect.Type) (string, error) {
+	if name, ok := g.names[typ]; ok {
+		return name, nil
+	}
+
+	name := typ.Name()
+	for i := 2; g.taken[name]; i++ {
+		name = typ.Name() + strconv.Itoa(i)
+	}
+
+	g.names[typ] = name
+	g.taken[name] = true
+
+	// Reserve a slot, so interfaces keep the order of appearance even if
+	// their fields declare other interfaces.
+	slot := len(g.decls)
+	g.decls = append(g.decls, "")
+
+	extends, body, err := g.body(typ, "  ")
+	if err != nil {
+		return "", err
+	}
+
+	decl := "export interface " + name
+	if len(extends) > 0 {
+		decl += " extends " + strings.Join(extends, ", ")
+	}
+
+	g.decls[slot] = decl + " " + body + "\n"
+
+	return name, nil
+}
+
+// body returns embedded interfaces and a "{ ... }" block of properties.
+func (g *generator) body(typ reflect.Type, indent string) ([]string, string, error) {
+	extends := make([]string, 0)
+	props := make([]string, 0, typ.NumField())
+
+	// Field names are unique within a struct, fields Extract skips, like
+	// blank ones, aren't found.
+	extracted := textra.Extract(reflect.Zero(reflect.PtrTo(typ)).Interface()).Index()
+
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+
+		field, ok := extracted.Field(sf.Name)
+		if !ok {
+			continue
+		}
+
+		tag, tagged := field.Tags.ByName("json")
+
+		if tagged && tag.Ignored() && len(tag.Optional) == 0 {
+			continue
+		}
+
+		override, skip := tsOverride(field)
+		if skip {
+			continue
+		}
+
+		ft := sf.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		if sf.Anonymous && tag.Value == "" && ft.Kind() == reflect.Struct && ft.Name() != "" && !isSpecial(ft) {
+			name, err := g.named(ft)
+			if err != nil {
+				return nil, "", err
+			}
+
+			extends = append(extends, name)
+
+			continue
+		}
+
+		if !isExported(sf.Name) {
+			continue
+		}
+
+		tsType := override
+		if tsType == "" {
+			var err error
+
+			if tsType, err = g.tsType(sf.Type, indent); err != nil {
+				return nil, "", fmt.Errorf("tsgen: %s.%s: %v", typ, sf.Name, err)
+			}
+
+			if tag.HasOption("string") {
+				tsType = "string"
+			}
+		}
+
+		name := tag.Value
+		if name == "" {
+			name = sf.Name
+		}
+
+		if !identRegexp.MatchString(name) {
+			name = strconv.Quote(name)
+		}
+
+		optional := ""
+		if tag.OmitEmpty() || sf.Type.Kind() == reflect.Ptr {
+			optional = "?"
+		}
+
+		props = append(props, indent+name+optional+": "+tsType+";")
+	}
+
+	closing := indent[:len(indent)-2]
+	if len(props) == 0 {
+		return extends, "{}", nil
+	}
+
+	return extends, "{\n" + strings.Join(props, "\n") + "\n" + closing + "}", nil
+}
+
+// tsType returns a TypeScript type of typ.
+func (g *generator) tsType(typ reflect.Type, indent string) (string, error) {
+	if typ.Kind() == reflect.Ptr {
+		return g.tsType(typ.Elem(), indent)
+	}
+
+	switch {
+	case typ == timeType:
+		return "string", nil
+	case implements(typ, jsonMarshalerType):
+		return "unknown", nil
+	case implements(typ, textMarshalerType):
+		return "string", nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "boolean", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "number", nil
+	case reflect.String:
+		return "string", nil
+	case reflect.Slice, reflect.Array:
+		if typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8 {
+			return "string", nil
+		}
+
+		elem, err := g.tsType(typ.Elem(), indent)
+		if err != nil {
+			return "", err
+		}
+
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+
+		return elem + "[]", nil
+	case reflect.Map:
+		key := "string"
+
+		switch typ.Key().Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			key = "number"
+		}
+
+		value, err := g.tsType(typ.Elem(), indent)
+		if err != nil {
+			return "", err
+		}
+
+		return "Record<" + key + ", " + value + ">", nil
+	case reflect.Struct:
+		if typ.Name() != "" {
+			return g.named(typ)
+		}
+
+		_, body, err := g.body(typ, indent+"  ")
+
+		return body, err
+	case reflect.Interface:
+		return "unknown", nil
+	default:
+		return "", fmt.Errorf("unsupported type %s, use ts:\"type=...\"", typ)
+	}
+}
+
+// tsOverride returns a type from a ts tag, or true if the field should be
+// skipped.
+func tsOverride(field textra.Field) (string, bool) {
+	tag, ok := field.Tags.ByName("ts")
+	if !ok {
+		return "", false
+	}
+
+	if tag.Ignored() {
+		return "", true
+	}
+
+	opts := strings.Split(tag.Raw(), ",")
+	for i, opt := range opts {
+		split := strings.SplitN(opt, "=", 2)
+		if len(split) == 2 && strings.TrimSpace(split[0]) == "type" {
+			// Everything after "type=" is the type, it may have commas.
+			typ := strings.Join(append([]string{split[1]}, opts[i+1:]...), ",")
+			return strings.TrimSpace(typ), false
+		}
+	}
+
+	return "", false
+}
+
+func isSpecial(typ reflect.Type) bool {
+	return typ == timeType || implements(typ, jsonMarshalerType) || implements(typ, textMarshalerType)
+}
+
+func implements(typ, iface reflect.Type) bool {
+	return typ.Implements(iface) || (typ.Kind() != reflect.Ptr && reflect.PtrTo(typ).Implements(iface))
+}
+
+func isExported(name string) bool {
+	r, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(r)
+}
@@ -0,0 +1,108 @@
+package tsgen_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ravsii/textra/tsgen"
+)
+
+type Base struct {
+	ID      int       `json:"id"`
+	Created time.Time `json:"created"`
+}
+
+type Address struct {
+	City string `json:"city"`
+}
+
+type User struct {
+	Base
+	Name     string             `json:"name"`
+	Email    *string            `json:"email"`
+	Nick     string             `json:"nick,omitempty"`
+	Tags     []string           `json:"tags"`
+	Scores   map[int][]*float64 `json:"scores"`
+	Home     Address            `json:"home"`
+	Work     *Address           `json:"work,omitempty"`
+	Friends  []User             `json:"friends"`
+	Meta     struct{ A int }    `json:"meta"`
+	Birthday string             `json:"birthday"             ts:"type=Date"`
+	Counts   string             `json:"counts"               ts:"type=Map<string, Record<number, string>>"`
+	Dashed   int                `json:"user-id,string"`
+	Any      interface{}        `json:"any"`
+	Hidden   string             `json:"-"`
+	Skipped  chan int           `ts:"-"`
+	private  int
+}
+
+func TestGenerate(t *testing.T) {
+	got, err := tsgen.Generate((*User)(nil), Address{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := `export interface User extends Base {
+  name: string;
+  email?: string;
+  nick?: string;
+  tags: string[];
+  scores: Record<number, number[]>;
+  home: Address;
+  work?: Address;
+  friends: User[];
+  meta: {
+    A: number;
+  };
+  birthday: Date;
+  counts: Map<string, Record<number, string>>;
+  "user-id": string;
+  any: unknown;
+}
+
+export interface Base {
+  id: number;
+  created: string;
+}
+
+export interface Address {
+  city: string;
+}
+`
+
+	if got != want {
+		t.Errorf("Generate() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGenerateBlankFields(t *testing.T) {
+	type Table struct {
+		_    struct{} `table:"t"`
+		Name string   `json:"name"`
+		Age  int      `json:"age"`
+	}
+
+	got, err := tsgen.Generate(Table{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := "export interface Table {\n  name: string;\n  age: number;\n}\n"
+	if got != want {
+		t.Errorf("Generate() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	type Unsupported struct {
+		Fn func() `json:"fn"`
+	}
+
+	if _, err := tsgen.Generate(Unsupported{}); err == nil {
+		t.Errorf("Generate() should fail for func fields")
+	}
+
+	if _, err := tsgen.Generate(42); err == nil {
+		t.Errorf("Generate() should fail for non-structs")
+	}
+}
@@ -0,0 +1,261 @@
+package textra
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidateTag is a parsed go-playground/validator tag, like
+//
+//	`validate:"required,min=3,dive,keys,alpha,endkeys,oneof=a b c|len=0"`.
+//
+// It's returned by Tag.Parse for validate tags.
+type ValidateTag struct {
+	// Rules apply to the field itself (or to an element, for dive levels).
+	Rules []ValidateRule `json:"rules,omitempty"`
+	// Keys holds rules for map keys, which are listed between "keys" and
+	// "endkeys" right after "dive". It's only set on dive levels.
+	Keys []ValidateRule `json:"keys,omitempty"`
+	// Dive holds rules for elements of a slice, array or map,
+	// which come after "dive".
+	Dive *ValidateTag `json:"dive,omitempty"`
+}
+
+// ValidateRule is a comma-separated part of a validate tag. It passes if any
+// of its checks passes.
+type ValidateRule struct {
+	// Checks holds alternatives separated by "|". Most rules have only one.
+	Checks []ValidateCheck `json:"checks"`
+}
+
+// ValidateCheck is a single check, like "min=3".
+type ValidateCheck struct {
+	Name string `json:"name"`
+	// Param holds everything after "=", with escaped commas (0x2C) and
+	// pipes (0x7C) replaced.
+	Param string `json:"param,omitempty"`
+}
+
+// ValidateRef is a reference from a field's check to another field,
+// like "eqfield=Password".
+type ValidateRef struct {
+	// Field is a name of a field which has the check.
+	Field string `json:"field"`
+	// Check is a check's name, like "eqfield".
+	Check string `json:"check"`
+	// Target is a referenced field, like "Password" or "Inner.Password".
+	Target string `json:"target"`
+	// Found is true if Target's first segment is a field of the same Struct.
+	Found bool `json:"found"`
+}
+
+// validateRefParams lists checks that reference other fields and how
+// their params are laid out.
+var validateRefParams = map[string]validateRefKind{
+	"eqfield": refSingle, "nefield": refSingle, "gtfield": refSingle,
+	"gtefield": refSingle, "ltfield": refSingle, "ltefield": refSingle,
+	"eqcsfield": refSingle, "necsfield": refSingle, "gtcsfield": refSingle,
+	"gtecsfield": refSingle, "ltcsfield": refSingle, "ltecsfield": refSingle,
+	"fieldcontains": refSingle, "fieldexcludes": refSingle,
+
+	"required_with": refList, "required_with_all": refList,
+	"required_without": refList, "required_without_all": refList,
+	"excluded_with": refList, "excluded_with_all": refList,
+	"excluded_without": refList, "excluded_without_all": refList,
+
+	"required_if": refPairs, "required_unless": refPairs,
+	"excluded_if": refPairs, "excluded_unless": refPairs,
+	"skip_unless": refPairs,
+}
+
+type validateRefKind int
+
+const (
+	// refSingle is a single field name, like "eqfield=Password".
+	refSingle validateRefKind = iota
+	// refList is a list of field names, like "required_with=A B".
+	refList
+	// refPairs is a list of field name and value pairs, like
+	// "required_if=A 1 B 2".
+	refPairs
+)
+
+var validateTagParser = TagParserFunc(func(tag Tag) (interface{}, error) {
+	return ParseValidateTag(tag)
+})
+
+var validateParamReplacer = strings.NewReplacer("0x2C", ",", "0x7C", "|")
+
+// ParseValidateTag parses a validate tag.
+func ParseValidateTag(tag Tag) (ValidateTag, error) {
+	parsed := ValidateTag{}
+	level := &parsed
+	inKeys := false
+	prev := ""
+
+	for _, part := range strings.Split(tag.Raw(), ",") {
+		part = strings.TrimSpace(part)
+
+		switch part {
+		case "":
+			return ValidateTag{}, errors.New("textra: validate: empty rule")
+		case "dive":
+			if inKeys {
+				return ValidateTag{}, errors.New("textra: validate: dive inside keys")
+			}
+
+			level.Dive = &ValidateTag{}
+			level = level.Dive
+		case "keys":
+			if prev != "dive" {
+				return ValidateTag{}, errors.New("textra: validate: keys must follow dive")
+			}
+
+			inKeys = true
+		case "endkeys":
+			if !inKeys {
+				return ValidateTag{}, errors.New("textra: validate: endkeys without keys")
+			}
+
+			inKeys = false
+		default:
+			rule, err := parseValidateRule(part)
+			if err != nil {
+				return ValidateTag{}, err
+			}
+
+			if inKeys {
+				level.Keys = append(level.Keys, rule)
+			} else {
+				level.Rules = append(level.Rules, rule)
+			}
+		}
+
+		prev = part
+	}
+
+	if inKeys {
+		return ValidateTag{}, errors.New("textra: validate: keys without endkeys")
+	}
+
+	return parsed, nil
+}
+
+func parseValidateRule(part string) (ValidateRule, error) {
+	alternatives := strings.Split(part, "|")
+	rule := ValidateRule{Checks: make([]ValidateCheck, 0, len(alternatives))}
+
+	for _, alt := range alternatives {
+		split := strings.SplitN(alt, "=", 2)
+		check := ValidateCheck{Name: strings.TrimSpace(split[0])}
+
+		if check.Name == "" {
+			return ValidateRule{}, fmt.Errorf("textra: validate: empty check in %q", part)
+		}
+
+		if len(split) == 2 {
+			check.Param = validateParamReplacer.Replace(split[1])
+		}
+
+		rule.Checks = append(rule.Checks, check)
+	}
+
+	return rule, nil
+}
+
+// Params splits c.Param by spaces, which is how lists like
+// "oneof=a b c" are written.
+func (c ValidateCheck) Params() []string {
+	return strings.Fields(c.Param)
+}
+
+// Check returns the first check with the given name from t.Rules.
+// Keys and dive levels are not searched.
+func (t ValidateTag) Check(name string) (ValidateCheck, bool) {
+	for _, rule := range t.Rules {
+		for _, check := range rule.Checks {
+			if check.Name == name {
+				return check, true
+			}
+		}
+	}
+
+	return ValidateCheck{}, false
+}
+
+// Refs returns references of checks like "eqfield" or "required_with"
+// to other fields, including the ones from keys and dive levels.
+// ValidateRef.Field and ValidateRef.Found are left empty.
+func (t ValidateTag) Refs() []ValidateRef {
+	refs := make([]ValidateRef, 0)
+
+	for level := &t; level != nil; level = level.Dive {
+		for _, rules := range [][]ValidateRule{level.Keys, level.Rules} {
+			for _, rule := range rules {
+				for _, check := range rule.Checks {
+					refs = append(refs, check.refs()...)
+				}
+			}
+		}
+	}
+
+	return refs
+}
+
+func (c ValidateCheck) refs() []ValidateRef {
+	kind, ok := validateRefParams[c.Name]
+	if !ok {
+		return nil
+	}
+
+	var targets []string
+
+	switch kind {
+	case refSingle:
+		targets = []string{strings.TrimSpace(c.Param)}
+	case refList:
+		targets = c.Params()
+	case refPairs:
+		params := c.Params()
+		for i := 0; i < len(params); i += 2 {
+			targets = append(targets, params[i])
+		}
+	}
+
+	refs := make([]ValidateRef, 0, len(targets))
+	for _, target := range targets {
+		if target != "" {
+			refs = append(refs, ValidateRef{Check: c.Name, Target: target})
+		}
+	}
+
+	return refs
+}
+
+// ValidateRefs returns all cross-field references from validate tags of s,
+// checked against the fields of s. For cross-struct references, like
+// "eqcsfield=Inner.Field", only the first segment is checked.
+func (s Struct) ValidateRefs() ([]ValidateRef, error) {
+	refs := make([]ValidateRef, 0)
+
+	for _, field := range s {
+		tag, ok := field.Tags.ByName("validate")
+		if !ok {
+			continue
+		}
+
+		validate, err := ParseValidateTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", field.Name, err)
+		}
+
+		for _, ref := range validate.Refs() {
+			ref.Field = field.Name
+			_, ref.Found = s.Field(strings.SplitN(ref.Target, ".", 2)[0])
+			refs = append(refs, ref)
+		}
+	}
+
+	return refs, nil
+}
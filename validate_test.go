@@ -0,0 +1,100 @@
+package textra_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ravsii/textra"
+)
+
+func TestParseValidateTag(t *testing.T) {
+	type check = textra.ValidateCheck
+
+	rule := func(checks ...check) textra.ValidateRule {
+		return textra.ValidateRule{Checks: checks}
+	}
+
+	testCases := []struct {
+		name    string
+		raw     string
+		want    textra.ValidateTag
+		wantErr bool
+	}{
+		{"simple", "required,min=3,max=10", textra.ValidateTag{Rules: []textra.ValidateRule{
+			rule(check{Name: "required"}),
+			rule(check{Name: "min", Param: "3"}),
+			rule(check{Name: "max", Param: "10"}),
+		}}, false},
+		{"dive with keys", "required,dive,keys,alpha,endkeys,oneof=a b c|len=0", textra.ValidateTag{
+			Rules: []textra.ValidateRule{rule(check{Name: "required"})},
+			Dive: &textra.ValidateTag{
+				Keys:  []textra.ValidateRule{rule(check{Name: "alpha"})},
+				Rules: []textra.ValidateRule{rule(check{Name: "oneof", Param: "a b c"}, check{Name: "len", Param: "0"})},
+			},
+		}, false},
+		{"nested dive", "dive,dive,required", textra.ValidateTag{
+			Dive: &textra.ValidateTag{Dive: &textra.ValidateTag{
+				Rules: []textra.ValidateRule{rule(check{Name: "required"})},
+			}},
+		}, false},
+		{"escaped", "contains=0x2C|excludes=0x7C", textra.ValidateTag{Rules: []textra.ValidateRule{
+			rule(check{Name: "contains", Param: ","}, check{Name: "excludes", Param: "|"}),
+		}}, false},
+		{"keys without dive", "keys,alpha,endkeys", textra.ValidateTag{}, true},
+		{"keys without endkeys", "dive,keys,alpha", textra.ValidateTag{}, true},
+		{"endkeys without keys", "dive,endkeys", textra.ValidateTag{}, true},
+		{"empty check", "required,|min=1", textra.ValidateTag{}, true},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		got, err := textra.ParseValidateTag(textra.Tag{Tag: "validate", Value: testCase.raw})
+		if (err != nil) != testCase.wantErr {
+			t.Errorf("%s: err %v, wantErr %t", testCase.name, err, testCase.wantErr)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, testCase.want) {
+			t.Errorf("%s: got %+v want %+v", testCase.name, got, testCase.want)
+		}
+	}
+}
+
+func TestValidateRefs(t *testing.T) {
+	type Tester struct {
+		Password string   `validate:"required"`
+		Confirm  string   `validate:"required,eqfield=Password"`
+		Phone    string   `validate:"required_without=Email Fax"`
+		Role     string   `validate:"required_if=Kind admin Level 2"`
+		Inner    struct{} `validate:"eqcsfield=Inner.Name"`
+		Kind     string
+	}
+
+	data := textra.Extract((*Tester)(nil))
+
+	got, err := data.ValidateRefs()
+	if err != nil {
+		t.Fatalf("ValidateRefs() error = %v", err)
+	}
+
+	want := []textra.ValidateRef{
+		{Field: "Confirm", Check: "eqfield", Target: "Password", Found: true},
+		{Field: "Phone", Check: "required_without", Target: "Email", Found: false},
+		{Field: "Phone", Check: "required_without", Target: "Fax", Found: false},
+		{Field: "Role", Check: "required_if", Target: "Kind", Found: true},
+		{Field: "Role", Check: "required_if", Target: "Level", Found: false},
+		{Field: "Inner", Check: "eqcsfield", Target: "Inner.Name", Found: true},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateRefs() = %+v, want %+v", got, want)
+	}
+
+	field, _ := data.Field("Confirm")
+
+	parsed, _, _ := field.Tags.ParseByName("validate")
+	if check, ok := parsed.(textra.ValidateTag).Check("eqfield"); !ok || check.Param != "Password" {
+		t.Errorf("Check(eqfield) = %+v, %t", check, ok)
+	}
+}
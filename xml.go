@@ -0,0 +1,207 @@
+package textra
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// XMLKind tells how encoding/xml treats a field.
+type XMLKind string
+
+// Possible XMLKind values, mirroring encoding/xml tag options.
+const (
+	XMLElement  XMLKind = "element"
+	XMLAttr     XMLKind = "attr"
+	XMLCharData XMLKind = "chardata"
+	XMLCData    XMLKind = "cdata"
+	XMLInnerXML XMLKind = "innerxml"
+	XMLComment  XMLKind = "comment"
+	XMLAny      XMLKind = "any"
+)
+
+// XMLTag is a parsed xml tag, like
+//
+//	`xml:"http://example.com/ns a>b>c,omitempty"`.
+//
+// It's returned by Tag.Parse for xml tags.
+type XMLTag struct {
+	Kind XMLKind `json:"kind"`
+	// Namespace is set if the tag starts with "namespace-URL name".
+	Namespace string `json:"namespace,omitempty"`
+	// Name is a local name, which is empty if encoding/xml should use
+	// the field's name.
+	Name string `json:"name,omitempty"`
+	// Parents holds parent elements of "a>b>c" paths, which are "a" and "b".
+	Parents []string `json:"parents,omitempty"`
+	// Any is true for ",any" and ",any,attr".
+	Any       bool `json:"any,omitempty"`
+	OmitEmpty bool `json:"omitempty,omitempty"`
+	// Ignored is true for "-".
+	Ignored bool `json:"ignored,omitempty"`
+}
+
+// XMLField is a field of a Struct, as encoding/xml sees it.
+type XMLField struct {
+	// Field is a struct field's name.
+	Field string `json:"field"`
+	Type  string `json:"type"`
+	// Name is a resolved local name, which is either the tag's name or the
+	// field's name. It's empty for chardata, cdata, innerxml, comment and
+	// any fields (including ",any,attr").
+	Name string `json:"name,omitempty"`
+	Tag  XMLTag `json:"tag"`
+}
+
+var xmlTagParser = TagParserFunc(func(tag Tag) (interface{}, error) {
+	return ParseXMLTag(tag)
+})
+
+// ParseXMLTag parses an xml tag using the same rules encoding/xml does,
+// including the errors for invalid combinations of options.
+func ParseXMLTag(tag Tag) (XMLTag, error) {
+	parsed := XMLTag{Kind: XMLElement}
+
+	if tag.Ignored() {
+		parsed.Ignored = true
+		return parsed, nil
+	}
+
+	modes := 0
+
+	for _, opt := range tag.Optional {
+		switch opt {
+		case "attr", "chardata", "cdata", "innerxml", "comment":
+			parsed.Kind = XMLKind(opt)
+			modes++
+		case "any":
+			parsed.Any = true
+			modes++
+		case "omitempty":
+			parsed.OmitEmpty = true
+		}
+	}
+
+	switch {
+	case parsed.Any && parsed.Kind == XMLAttr:
+		modes--
+	case parsed.Any:
+		parsed.Kind = XMLAny
+	}
+
+	if modes > 1 {
+		return XMLTag{}, fmt.Errorf("textra: xml: invalid tag %q", tag.Raw())
+	}
+
+	name := tag.Value
+	if i := strings.Index(name, " "); i >= 0 {
+		parsed.Namespace, name = name[:i], name[i+1:]
+	}
+
+	named := parsed.Kind == XMLElement || (parsed.Kind == XMLAttr && !parsed.Any)
+	if !named && tag.Value != "" {
+		return XMLTag{}, fmt.Errorf("textra: xml: %s field can't have a name", parsed.Kind)
+	}
+
+	path := strings.Split(name, ">")
+	parsed.Name = path[len(path)-1]
+
+	if len(path) > 1 {
+		if parsed.Kind != XMLElement {
+			return XMLTag{}, fmt.Errorf("textra: xml: %s field can't have parents", parsed.Kind)
+		}
+
+		parsed.Parents = path[:len(path)-1]
+		for _, parent := range parsed.Parents {
+			if parent == "" {
+				return XMLTag{}, errors.New("textra: xml: empty parent in " + name)
+			}
+		}
+
+		if parsed.Name == "" {
+			return XMLTag{}, errors.New("textra: xml: trailing '>' in " + name)
+		}
+	}
+
+	return parsed, nil
+}
+
+// Path returns the tag's name with its parents, like "a>b>c".
+func (t XMLTag) Path() string {
+	return strings.Join(append(append([]string(nil), t.Parents...), t.Name), ">")
+}
+
+// XMLName returns a namespace and a local name of the element, which are set
+// by a tag of an XMLName field. False is returned if there's no such field
+// or it has no xml tag.
+func (s Struct) XMLName() (string, string, bool) {
+	field, ok := s.Field("XMLName")
+	if !ok {
+		return "", "", false
+	}
+
+	tag, ok := field.Tags.ByName("xml")
+	if !ok {
+		return "", "", false
+	}
+
+	parsed, err := ParseXMLTag(tag)
+	if err != nil || parsed.Ignored {
+		return "", "", false
+	}
+
+	return parsed.Namespace, parsed.Name, true
+}
+
+// XMLFields returns fields of s as encoding/xml sees them. Exported fields
+// without xml tags are elements named after the field. Ignored, unexported
+// and embedded fields are skipped, as well as the XMLName field.
+func (s Struct) XMLFields() ([]XMLField, error) {
+	fields := make([]XMLField, 0, len(s))
+
+	for _, field := range s {
+		if field.Name == "XMLName" || field.Embedded || !isExported(field.Name) {
+			continue
+		}
+
+		parsed := XMLTag{Kind: XMLElement}
+
+		if tag, ok := field.Tags.ByName("xml"); ok {
+			var err error
+
+			parsed, err = ParseXMLTag(tag)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", field.Name, err)
+			}
+		}
+
+		if parsed.Ignored {
+			continue
+		}
+
+		name := ""
+
+		if parsed.Kind == XMLElement || (parsed.Kind == XMLAttr && !parsed.Any) {
+			name = parsed.Name
+			if name == "" {
+				name = field.Name
+			}
+		}
+
+		fields = append(fields, XMLField{
+			Field: field.Name,
+			Type:  field.Type,
+			Name:  name,
+			Tag:   parsed,
+		})
+	}
+
+	return fields, nil
+}
+
+func isExported(name string) bool {
+	r, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(r)
+}
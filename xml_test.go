@@ -0,0 +1,90 @@
+package textra_test
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+
+	"github.com/ravsii/textra"
+)
+
+func TestParseXMLTag(t *testing.T) {
+	testCases := []struct {
+		name    string
+		tag     textra.Tag
+		want    textra.XMLTag
+		wantErr bool
+	}{
+		{"element", textra.Tag{Tag: "xml", Value: "name", Optional: []string{"omitempty"}}, textra.XMLTag{
+			Kind: textra.XMLElement, Name: "name", OmitEmpty: true,
+		}, false},
+		{"path", textra.Tag{Tag: "xml", Value: "a>b>c"}, textra.XMLTag{
+			Kind: textra.XMLElement, Name: "c", Parents: []string{"a", "b"},
+		}, false},
+		{"namespace", textra.Tag{Tag: "xml", Value: "http://example.com/ns id", Optional: []string{"attr"}}, textra.XMLTag{
+			Kind: textra.XMLAttr, Namespace: "http://example.com/ns", Name: "id",
+		}, false},
+		{"chardata", textra.Tag{Tag: "xml", Value: "", Optional: []string{"chardata"}}, textra.XMLTag{Kind: textra.XMLCharData}, false},
+		{"any attr", textra.Tag{Tag: "xml", Value: "", Optional: []string{"any", "attr"}}, textra.XMLTag{Kind: textra.XMLAttr, Any: true}, false},
+		{"any", textra.Tag{Tag: "xml", Value: "", Optional: []string{"any"}}, textra.XMLTag{Kind: textra.XMLAny, Any: true}, false},
+		{"ignored", textra.Tag{Tag: "xml", Value: "-"}, textra.XMLTag{Kind: textra.XMLElement, Ignored: true}, false},
+		{"attr with parents", textra.Tag{Tag: "xml", Value: "a>b", Optional: []string{"attr"}}, textra.XMLTag{}, true},
+		{"named chardata", textra.Tag{Tag: "xml", Value: "text", Optional: []string{"chardata"}}, textra.XMLTag{}, true},
+		{"two modes", textra.Tag{Tag: "xml", Value: "", Optional: []string{"attr", "innerxml"}}, textra.XMLTag{}, true},
+		{"trailing >", textra.Tag{Tag: "xml", Value: "a>"}, textra.XMLTag{}, true},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		got, err := textra.ParseXMLTag(testCase.tag)
+		if (err != nil) != testCase.wantErr {
+			t.Errorf("%s: err %v, wantErr %t", testCase.name, err, testCase.wantErr)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, testCase.want) {
+			t.Errorf("%s: got %+v want %+v", testCase.name, got, testCase.want)
+		}
+	}
+
+	if path := (textra.XMLTag{Name: "c", Parents: []string{"a", "b"}}).Path(); path != "a>b>c" {
+		t.Errorf("Path() = %s, want a>b>c", path)
+	}
+}
+
+func TestXMLFields(t *testing.T) {
+	type Person struct {
+		XMLName xml.Name `xml:"urn:people person"`
+		ID      int      `xml:"id,attr"`
+		City    string   `xml:"address>city"`
+		Comment string   `xml:",comment"`
+		Email   string
+		Skip    string `xml:"-"`
+		private string
+	}
+
+	data := textra.Extract((*Person)(nil))
+
+	if ns, name, ok := data.XMLName(); !ok || ns != "urn:people" || name != "person" {
+		t.Errorf("XMLName() = %s, %s, %t", ns, name, ok)
+	}
+
+	got, err := data.XMLFields()
+	if err != nil {
+		t.Fatalf("XMLFields() error = %v", err)
+	}
+
+	want := []textra.XMLField{
+		{Field: "ID", Type: "int", Name: "id", Tag: textra.XMLTag{Kind: textra.XMLAttr, Name: "id"}},
+		{Field: "City", Type: "string", Name: "city", Tag: textra.XMLTag{
+			Kind: textra.XMLElement, Name: "city", Parents: []string{"address"},
+		}},
+		{Field: "Comment", Type: "string", Tag: textra.XMLTag{Kind: textra.XMLComment}},
+		{Field: "Email", Type: "string", Name: "Email", Tag: textra.XMLTag{Kind: textra.XMLElement}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("XMLFields() = %+v, want %+v", got, want)
+	}
+}